@@ -0,0 +1,78 @@
+package client
+
+import "context"
+
+// Seat Management
+
+// ActivateLicense occupies a seat of a license for the given device.
+// If all seats are taken the returned error matches ErrSeatLimitReached.
+func (c *LicenseChainClient) ActivateLicense(licenseKey, deviceID string, metadata map[string]interface{}) (*Activation, error) {
+	return c.ActivateLicenseCtx(context.Background(), licenseKey, deviceID, metadata)
+}
+
+// ActivateLicenseCtx occupies a seat of a license for the given device using the provided context
+func (c *LicenseChainClient) ActivateLicenseCtx(ctx context.Context, licenseKey, deviceID string, metadata map[string]interface{}) (*Activation, error) {
+	if err := ValidateNotEmpty(licenseKey, "license_key"); err != nil {
+		return nil, err
+	}
+	if err := ValidateNotEmpty(deviceID, "device_id"); err != nil {
+		return nil, err
+	}
+
+	req := ActivateLicenseRequest{
+		LicenseKey: licenseKey,
+		DeviceID:   deviceID,
+		Metadata:   SanitizeMetadata(metadata),
+	}
+	var response struct {
+		Data Activation `json:"data"`
+	}
+
+	err := c.makeRequest(ctx, "POST", "/licenses/activate", req, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+// DeactivateLicense frees the seat a device occupies on a license
+func (c *LicenseChainClient) DeactivateLicense(licenseKey, deviceID string) error {
+	return c.DeactivateLicenseCtx(context.Background(), licenseKey, deviceID)
+}
+
+// DeactivateLicenseCtx frees the seat a device occupies on a license using the provided context
+func (c *LicenseChainClient) DeactivateLicenseCtx(ctx context.Context, licenseKey, deviceID string) error {
+	if err := ValidateNotEmpty(licenseKey, "license_key"); err != nil {
+		return err
+	}
+	if err := ValidateNotEmpty(deviceID, "device_id"); err != nil {
+		return err
+	}
+
+	req := ActivateLicenseRequest{
+		LicenseKey: licenseKey,
+		DeviceID:   deviceID,
+	}
+	return c.makeRequest(ctx, "POST", "/licenses/deactivate", req, nil)
+}
+
+// ListActivations lists the active seats of a license
+func (c *LicenseChainClient) ListActivations(licenseID string) (*ActivationListResponse, error) {
+	return c.ListActivationsCtx(context.Background(), licenseID)
+}
+
+// ListActivationsCtx lists the active seats of a license using the provided context
+func (c *LicenseChainClient) ListActivationsCtx(ctx context.Context, licenseID string) (*ActivationListResponse, error) {
+	if err := validateID(licenseID, "license_id"); err != nil {
+		return nil, err
+	}
+
+	var response ActivationListResponse
+	err := c.makeRequest(ctx, "GET", "/licenses/"+licenseID+"/activations", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
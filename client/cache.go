@@ -0,0 +1,227 @@
+package client
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Cache is a key-value store for license state such as validation results.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value for key and whether it was found
+	Get(key string) ([]byte, bool, error)
+	// Set stores value under key
+	Set(key string, value []byte) error
+	// Delete removes key; deleting a missing key is not an error
+	Delete(key string) error
+}
+
+// MemoryCache is a Cache held in process memory
+type MemoryCache struct {
+	mu      sync.RWMutex
+	entries map[string][]byte
+}
+
+// NewMemoryCache creates an empty in-memory cache
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: make(map[string][]byte)}
+}
+
+// Get returns the value for key and whether it was found
+func (mc *MemoryCache) Get(key string) ([]byte, bool, error) {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+	value, ok := mc.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	return append([]byte(nil), value...), true, nil
+}
+
+// Set stores value under key
+func (mc *MemoryCache) Set(key string, value []byte) error {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	mc.entries[key] = append([]byte(nil), value...)
+	return nil
+}
+
+// Delete removes key
+func (mc *MemoryCache) Delete(key string) error {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	delete(mc.entries, key)
+	return nil
+}
+
+// fileCacheVersion prefixes every file written by FileCache
+const fileCacheVersion byte = 1
+
+// FileCacheOptions configures a FileCache
+type FileCacheOptions struct {
+	// Key overrides the machine-derived encryption key
+	Key []byte
+	// Salt is mixed into the machine-derived key so that applications on the
+	// same machine cannot read each other's cache
+	Salt string
+}
+
+// FileCache is a Cache that persists each entry as a file in a directory.
+//
+// Entries are written atomically and encrypted with AES-256-GCM under a key
+// derived from the machine ID, so a cache copied to another machine cannot
+// be read. Where no machine ID is available, a random key is generated once
+// and stored in the directory with 0600 permissions instead. The entry key
+// is authenticated alongside the value, so corrupted, truncated or swapped
+// files are rejected with an error matching ErrCacheTampered.
+//
+// The encryption key can be derived by any local user, so the integrity
+// check detects corruption but not deliberate tampering on the same
+// machine. Set FileCacheOptions.Key to use a secret of your own.
+type FileCache struct {
+	dir  string
+	aead cipher.AEAD
+	mu   sync.Mutex
+}
+
+// NewFileCache creates a file-backed cache in dir, creating it if needed
+func NewFileCache(dir string, opts FileCacheOptions) (*FileCache, error) {
+	if err := ValidateNotEmpty(dir, "dir"); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %v", err)
+	}
+
+	keyMaterial := opts.Key
+	if len(keyMaterial) == 0 {
+		fp, err := GetHardwareFingerprint(FingerprintOptions{
+			Components: []string{HardwareComponentMachineID, HardwareComponentCPU},
+			Salt:       opts.Salt,
+		})
+		// The CPU model alone is shared by many hosts, so the fingerprint is
+		// only used when it includes the machine ID
+		if err == nil && fp.Components[HardwareComponentMachineID] != "" {
+			keyMaterial = []byte(fp.ID)
+		} else if keyMaterial, err = loadInstallKey(filepath.Join(dir, fileCacheKeyName)); err != nil {
+			return nil, fmt.Errorf("failed to derive cache key: %v", err)
+		}
+	}
+	key := sha256.Sum256(append([]byte("licensechain-file-cache:"+opts.Salt+":"), keyMaterial...))
+
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileCache{dir: dir, aead: aead}, nil
+}
+
+// fileCacheKeyName is the file holding the per-install key used when no machine ID is available
+const fileCacheKeyName = "install.key"
+
+// loadInstallKey reads the random key at path, creating it if it does not exist
+func loadInstallKey(path string) ([]byte, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if errors.Is(err, os.ErrExist) {
+		key, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if len(key) != 32 {
+			return nil, fmt.Errorf("invalid install key in %s", path)
+		}
+		return key, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+	if _, err := file.Write(key); err != nil {
+		return nil, err
+	}
+	return key, file.Sync()
+}
+
+// Get returns the decrypted value for key and whether it was found
+func (fc *FileCache) Get(key string) ([]byte, bool, error) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	data, err := os.ReadFile(fc.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	nonceSize := fc.aead.NonceSize()
+	if len(data) < 1+nonceSize || data[0] != fileCacheVersion {
+		return nil, false, newCacheTamperedError(key)
+	}
+	nonce := data[1 : 1+nonceSize]
+	value, err := fc.aead.Open(nil, nonce, data[1+nonceSize:], []byte(key))
+	if err != nil {
+		return nil, false, newCacheTamperedError(key)
+	}
+	return value, true, nil
+}
+
+// Set encrypts value and atomically writes it under key
+func (fc *FileCache) Set(key string, value []byte) error {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	nonce := make([]byte, fc.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+
+	data := append([]byte{fileCacheVersion}, nonce...)
+	data = fc.aead.Seal(data, nonce, value, []byte(key))
+	return writeFileAtomic(fc.path(key), data, 0600)
+}
+
+// Delete removes the file for key
+func (fc *FileCache) Delete(key string) error {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	err := os.Remove(fc.path(key))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path returns the file holding key; names are hashed so keys never leak
+func (fc *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(fc.dir, hex.EncodeToString(sum[:])+".cache")
+}
+
+func newCacheTamperedError(key string) *LicenseChainError {
+	return &LicenseChainError{
+		Type:    ErrCacheTampered.Type,
+		Message: fmt.Sprintf("Cache entry %q failed integrity check", key),
+	}
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileCacheRoundTrip(t *testing.T) {
+	fc, err := NewFileCache(t.TempDir(), FileCacheOptions{Key: []byte("secret")})
+	require.NoError(t, err)
+
+	_, ok, err := fc.Get("missing")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, fc.Set("license", []byte("value")))
+	value, ok, err := fc.Get("license")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("value"), value)
+
+	require.NoError(t, fc.Delete("license"))
+	require.NoError(t, fc.Delete("license"))
+	_, ok, err = fc.Get("license")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestFileCacheDetectsTampering(t *testing.T) {
+	dir := t.TempDir()
+	fc, err := NewFileCache(dir, FileCacheOptions{Key: []byte("secret")})
+	require.NoError(t, err)
+	require.NoError(t, fc.Set("a", []byte("first")))
+	require.NoError(t, fc.Set("b", []byte("second")))
+
+	// Swapping files between keys fails the authenticated entry key
+	a, err := os.ReadFile(fc.path("a"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(fc.path("b"), a, 0600))
+	_, _, err = fc.Get("b")
+	assert.ErrorIs(t, err, ErrCacheTampered)
+
+	// So does flipping a byte
+	a[len(a)-1] ^= 1
+	require.NoError(t, os.WriteFile(fc.path("a"), a, 0600))
+	_, _, err = fc.Get("a")
+	assert.ErrorIs(t, err, ErrCacheTampered)
+
+	// And a different key cannot read the entries
+	other, err := NewFileCache(dir, FileCacheOptions{Key: []byte("other")})
+	require.NoError(t, err)
+	require.NoError(t, fc.Set("c", []byte("third")))
+	_, _, err = other.Get("c")
+	assert.ErrorIs(t, err, ErrCacheTampered)
+}
+
+func TestLoadInstallKeyIsStable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), fileCacheKeyName)
+
+	first, err := loadInstallKey(path)
+	require.NoError(t, err)
+	second, err := loadInstallKey(path)
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(first, second))
+	assert.Len(t, first, 32)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
@@ -2,70 +2,77 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 )
 
 // LicenseChainClient represents the main client for the LicenseChain API
 type LicenseChainClient struct {
-	apiKey   string
-	baseURL  string
-	timeout  time.Duration
-	retries  int
-	client   *http.Client
+	apiKey      string
+	baseURL     string
+	userAgent   string
+	apiVersion  string
+	headers     http.Header
+	retryPolicy RetryPolicy
+	client      *http.Client
+
+	validationCache *validationCache
 }
 
-// NewClient creates a new LicenseChain client
-func NewClient(apiKey, baseURL string, timeout time.Duration, retries int) *LicenseChainClient {
-	if baseURL == "" {
-		baseURL = "https://api.licensechain.app"
-	}
-	if timeout <= 0 {
-		timeout = 30 * time.Second
-	}
-	if retries <= 0 {
-		retries = 3
+// NewClient creates a new LicenseChain client configured by the given options
+func NewClient(apiKey string, opts ...Option) *LicenseChainClient {
+	cfg := newClientConfig()
+	for _, opt := range opts {
+		opt(cfg)
 	}
 
-	return &LicenseChainClient{
-		apiKey:  apiKey,
-		baseURL: baseURL,
-		timeout: timeout,
-		retries: retries,
-		client: &http.Client{
-			Timeout: timeout,
-		},
+	c := &LicenseChainClient{
+		apiKey:      apiKey,
+		baseURL:     cfg.baseURL,
+		userAgent:   cfg.userAgent,
+		apiVersion:  cfg.apiVersion,
+		headers:     cfg.headers,
+		retryPolicy: cfg.retryPolicy,
+		client:      cfg.buildHTTPClient(),
+	}
+	if cfg.validationCache != nil {
+		c.validationCache = newValidationCache(*cfg.validationCache)
 	}
+	return c
 }
 
 // CreateClient creates a new client with default settings
 func CreateClient(apiKey string, baseURL ...string) *LicenseChainClient {
-	url := "https://api.licensechain.app"
 	if len(baseURL) > 0 {
-		url = baseURL[0]
+		return NewClient(apiKey, WithBaseURL(baseURL[0]))
 	}
-	return NewClient(apiKey, url, 30*time.Second, 3)
+	return NewClient(apiKey)
 }
 
 // FromEnvironment creates a client from environment variables
-func FromEnvironment() *LicenseChainClient {
+func FromEnvironment(opts ...Option) *LicenseChainClient {
 	apiKey := os.Getenv("LICENSECHAIN_API_KEY")
 	baseURL := os.Getenv("LICENSECHAIN_BASE_URL")
-	if baseURL == "" {
-		baseURL = "https://api.licensechain.app"
-	}
-	return NewClient(apiKey, baseURL, 30*time.Second, 3)
+	return NewClient(apiKey, append([]Option{WithBaseURL(baseURL)}, opts...)...)
 }
 
 // License Management
 
 // CreateLicense creates a new license
 func (c *LicenseChainClient) CreateLicense(req CreateLicenseRequest) (*License, error) {
+	return c.CreateLicenseCtx(context.Background(), req)
+}
+
+// CreateLicenseCtx creates a new license using the provided context
+func (c *LicenseChainClient) CreateLicenseCtx(ctx context.Context, req CreateLicenseRequest) (*License, error) {
 	if err := ValidateNotEmpty(req.UserID, "user_id"); err != nil {
 		return nil, err
 	}
@@ -79,7 +86,7 @@ func (c *LicenseChainClient) CreateLicense(req CreateLicenseRequest) (*License,
 		Data License `json:"data"`
 	}
 	
-	err := c.makeRequest("POST", "/licenses", req, &response)
+	err := c.makeRequest(ctx, "POST", "/licenses", req, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -89,18 +96,20 @@ func (c *LicenseChainClient) CreateLicense(req CreateLicenseRequest) (*License,
 
 // GetLicense retrieves a license by ID
 func (c *LicenseChainClient) GetLicense(licenseID string) (*License, error) {
-	if err := ValidateNotEmpty(licenseID, "license_id"); err != nil {
+	return c.GetLicenseCtx(context.Background(), licenseID)
+}
+
+// GetLicenseCtx retrieves a license by ID using the provided context
+func (c *LicenseChainClient) GetLicenseCtx(ctx context.Context, licenseID string) (*License, error) {
+	if err := validateID(licenseID, "license_id"); err != nil {
 		return nil, err
 	}
-	if !ValidateUUID(licenseID) {
-		return nil, NewValidationError("Invalid license_id format")
-	}
 
 	var response struct {
 		Data License `json:"data"`
 	}
 	
-	err := c.makeRequest("GET", "/licenses/"+licenseID, nil, &response)
+	err := c.makeRequest(ctx, "GET", "/licenses/"+licenseID, nil, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -110,29 +119,764 @@ func (c *LicenseChainClient) GetLicense(licenseID string) (*License, error) {
 
 // ValidateLicense validates a license key
 func (c *LicenseChainClient) ValidateLicense(licenseKey string) (bool, error) {
-	if err := ValidateNotEmpty(licenseKey, "license_key"); err != nil {
+	return c.ValidateLicenseCtx(context.Background(), licenseKey)
+}
+
+// ValidateLicenseCtx validates a license key using the provided context
+func (c *LicenseChainClient) ValidateLicenseCtx(ctx context.Context, licenseKey string) (bool, error) {
+	result, err := c.ValidateLicenseDetailedCtx(ctx, licenseKey)
+	if err != nil {
 		return false, err
 	}
 
-	req := map[string]string{"license_key": licenseKey}
+	return result.Valid, nil
+}
+
+// ValidateLicenseDetailed validates a license key and reports why it is
+// invalid and what it grants
+func (c *LicenseChainClient) ValidateLicenseDetailed(licenseKey string, opts ...ValidateOption) (*ValidationResult, error) {
+	return c.ValidateLicenseDetailedCtx(context.Background(), licenseKey, opts...)
+}
+
+// ValidateLicenseDetailedCtx validates a license key and reports why it is
+// invalid and what it grants using the provided context
+func (c *LicenseChainClient) ValidateLicenseDetailedCtx(ctx context.Context, licenseKey string, opts ...ValidateOption) (*ValidationResult, error) {
+	if err := ValidateNotEmpty(licenseKey, "license_key"); err != nil {
+		return nil, err
+	}
+
+	req := ValidateLicenseRequest{LicenseKey: licenseKey}
+	for _, opt := range opts {
+		opt(&req)
+	}
+
+	if c.validationCache != nil {
+		return c.validationCache.get(ctx, req, func(ctx context.Context) (*ValidationResult, error) {
+			return c.validateLicense(ctx, req)
+		})
+	}
+	return c.validateLicense(ctx, req)
+}
+
+// ListLicenses lists licenses with pagination and optional filters
+func (c *LicenseChainClient) ListLicenses(req ListLicensesRequest) (*LicenseListResponse, error) {
+	return c.ListLicensesCtx(context.Background(), req)
+}
+
+// ListLicensesCtx lists licenses with pagination and optional filters using the provided context
+func (c *LicenseChainClient) ListLicensesCtx(ctx context.Context, req ListLicensesRequest) (*LicenseListResponse, error) {
+	if req.Status != "" && !ValidateLicenseStatus(req.Status) {
+		return nil, NewValidationError(fmt.Sprintf("Invalid status: %s", req.Status))
+	}
+
+	query := paginationQuery(req.Page, req.Limit)
+	if req.Status != "" {
+		query.Set("status", req.Status)
+	}
+	if req.UserID != "" {
+		query.Set("user_id", req.UserID)
+	}
+	if req.ProductID != "" {
+		query.Set("product_id", req.ProductID)
+	}
+
+	var response LicenseListResponse
+	err := c.makeRequest(ctx, "GET", "/licenses?"+query.Encode(), nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// UpdateLicense updates a license
+func (c *LicenseChainClient) UpdateLicense(licenseID string, req UpdateLicenseRequest) (*License, error) {
+	return c.UpdateLicenseCtx(context.Background(), licenseID, req)
+}
+
+// UpdateLicenseCtx updates a license using the provided context
+func (c *LicenseChainClient) UpdateLicenseCtx(ctx context.Context, licenseID string, req UpdateLicenseRequest) (*License, error) {
+	if err := validateID(licenseID, "license_id"); err != nil {
+		return nil, err
+	}
+	if req.Status != "" && !ValidateLicenseStatus(req.Status) {
+		return nil, NewValidationError(fmt.Sprintf("Invalid status: %s", req.Status))
+	}
+
+	if req.Metadata != nil {
+		req.Metadata = SanitizeMetadata(req.Metadata)
+	}
+
 	var response struct {
-		Valid bool `json:"valid"`
+		Data License `json:"data"`
 	}
-	
-	err := c.makeRequest("POST", "/licenses/validate", req, &response)
+
+	err := c.makeRequest(ctx, "PATCH", "/licenses/"+licenseID, req, &response)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
-	
-	return response.Valid, nil
+
+	return &response.Data, nil
+}
+
+// RevokeLicense revokes a license
+func (c *LicenseChainClient) RevokeLicense(licenseID, reason string) (*License, error) {
+	return c.RevokeLicenseCtx(context.Background(), licenseID, reason)
+}
+
+// RevokeLicenseCtx revokes a license using the provided context
+func (c *LicenseChainClient) RevokeLicenseCtx(ctx context.Context, licenseID, reason string) (*License, error) {
+	req := map[string]string{}
+	if reason != "" {
+		req["reason"] = SanitizeInput(reason)
+	}
+	return c.licenseAction(ctx, licenseID, "revoke", req)
+}
+
+// SuspendLicense temporarily suspends a license
+func (c *LicenseChainClient) SuspendLicense(licenseID, reason string) (*License, error) {
+	return c.SuspendLicenseCtx(context.Background(), licenseID, reason)
+}
+
+// SuspendLicenseCtx temporarily suspends a license using the provided context
+func (c *LicenseChainClient) SuspendLicenseCtx(ctx context.Context, licenseID, reason string) (*License, error) {
+	req := map[string]string{}
+	if reason != "" {
+		req["reason"] = SanitizeInput(reason)
+	}
+	return c.licenseAction(ctx, licenseID, "suspend", req)
+}
+
+// ReinstateLicense reinstates a suspended license
+func (c *LicenseChainClient) ReinstateLicense(licenseID string) (*License, error) {
+	return c.ReinstateLicenseCtx(context.Background(), licenseID)
+}
+
+// ReinstateLicenseCtx reinstates a suspended license using the provided context
+func (c *LicenseChainClient) ReinstateLicenseCtx(ctx context.Context, licenseID string) (*License, error) {
+	return c.licenseAction(ctx, licenseID, "reinstate", nil)
+}
+
+// RenewLicense extends a license's expiry by the given duration
+func (c *LicenseChainClient) RenewLicense(licenseID string, duration time.Duration) (*License, error) {
+	return c.RenewLicenseCtx(context.Background(), licenseID, duration)
+}
+
+// RenewLicenseCtx extends a license's expiry by the given duration using the provided context
+func (c *LicenseChainClient) RenewLicenseCtx(ctx context.Context, licenseID string, duration time.Duration) (*License, error) {
+	if err := ValidatePositive(duration.Seconds(), "duration"); err != nil {
+		return nil, err
+	}
+
+	req := map[string]int64{"duration_seconds": int64(duration / time.Second)}
+	return c.licenseAction(ctx, licenseID, "renew", req)
+}
+
+// DeleteLicense permanently deletes a license
+func (c *LicenseChainClient) DeleteLicense(licenseID string) error {
+	return c.DeleteLicenseCtx(context.Background(), licenseID)
+}
+
+// DeleteLicenseCtx permanently deletes a license using the provided context
+func (c *LicenseChainClient) DeleteLicenseCtx(ctx context.Context, licenseID string) error {
+	if err := validateID(licenseID, "license_id"); err != nil {
+		return err
+	}
+
+	return c.makeRequest(ctx, "DELETE", "/licenses/"+licenseID, nil, nil)
+}
+
+// GetLicenseStats retrieves license statistics
+func (c *LicenseChainClient) GetLicenseStats() (*LicenseStats, error) {
+	return c.GetLicenseStatsCtx(context.Background())
+}
+
+// GetLicenseStatsCtx retrieves license statistics using the provided context
+func (c *LicenseChainClient) GetLicenseStatsCtx(ctx context.Context) (*LicenseStats, error) {
+	var response struct {
+		Data LicenseStats `json:"data"`
+	}
+
+	err := c.makeRequest(ctx, "GET", "/licenses/stats", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+// User Management
+
+// CreateUser creates a new user
+func (c *LicenseChainClient) CreateUser(req CreateUserRequest) (*User, error) {
+	return c.CreateUserCtx(context.Background(), req)
+}
+
+// CreateUserCtx creates a new user using the provided context
+func (c *LicenseChainClient) CreateUserCtx(ctx context.Context, req CreateUserRequest) (*User, error) {
+	if err := ValidateNotEmpty(req.Email, "email"); err != nil {
+		return nil, err
+	}
+	if !ValidateEmail(req.Email) {
+		return nil, NewValidationError("Invalid email format")
+	}
+	if err := ValidateNotEmpty(req.Name, "name"); err != nil {
+		return nil, err
+	}
+
+	req.Metadata = SanitizeMetadata(req.Metadata)
+
+	var response struct {
+		Data User `json:"data"`
+	}
+
+	err := c.makeRequest(ctx, "POST", "/users", req, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+// GetUser retrieves a user by ID
+func (c *LicenseChainClient) GetUser(userID string) (*User, error) {
+	return c.GetUserCtx(context.Background(), userID)
+}
+
+// GetUserCtx retrieves a user by ID using the provided context
+func (c *LicenseChainClient) GetUserCtx(ctx context.Context, userID string) (*User, error) {
+	if err := validateID(userID, "user_id"); err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data User `json:"data"`
+	}
+
+	err := c.makeRequest(ctx, "GET", "/users/"+userID, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+// GetUserByEmail retrieves a user by email address
+func (c *LicenseChainClient) GetUserByEmail(email string) (*User, error) {
+	return c.GetUserByEmailCtx(context.Background(), email)
+}
+
+// GetUserByEmailCtx retrieves a user by email address using the provided context
+func (c *LicenseChainClient) GetUserByEmailCtx(ctx context.Context, email string) (*User, error) {
+	if err := ValidateNotEmpty(email, "email"); err != nil {
+		return nil, err
+	}
+	if !ValidateEmail(email) {
+		return nil, NewValidationError("Invalid email format")
+	}
+
+	var response struct {
+		Data User `json:"data"`
+	}
+
+	query := url.Values{}
+	query.Set("email", email)
+	err := c.makeRequest(ctx, "GET", "/users/lookup?"+query.Encode(), nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+// ListUsers lists users with pagination
+func (c *LicenseChainClient) ListUsers(page, limit int) (*UserListResponse, error) {
+	return c.ListUsersCtx(context.Background(), page, limit)
+}
+
+// ListUsersCtx lists users with pagination using the provided context
+func (c *LicenseChainClient) ListUsersCtx(ctx context.Context, page, limit int) (*UserListResponse, error) {
+	var response UserListResponse
+	err := c.makeRequest(ctx, "GET", "/users?"+paginationQuery(page, limit).Encode(), nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// UpdateUser updates a user
+func (c *LicenseChainClient) UpdateUser(userID string, req UpdateUserRequest) (*User, error) {
+	return c.UpdateUserCtx(context.Background(), userID, req)
+}
+
+// UpdateUserCtx updates a user using the provided context
+func (c *LicenseChainClient) UpdateUserCtx(ctx context.Context, userID string, req UpdateUserRequest) (*User, error) {
+	if err := validateID(userID, "user_id"); err != nil {
+		return nil, err
+	}
+	if req.Email != "" && !ValidateEmail(req.Email) {
+		return nil, NewValidationError("Invalid email format")
+	}
+
+	if req.Metadata != nil {
+		req.Metadata = SanitizeMetadata(req.Metadata)
+	}
+
+	var response struct {
+		Data User `json:"data"`
+	}
+
+	err := c.makeRequest(ctx, "PATCH", "/users/"+userID, req, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+// DeleteUser deletes a user
+func (c *LicenseChainClient) DeleteUser(userID string) error {
+	return c.DeleteUserCtx(context.Background(), userID)
+}
+
+// DeleteUserCtx deletes a user using the provided context
+func (c *LicenseChainClient) DeleteUserCtx(ctx context.Context, userID string) error {
+	if err := validateID(userID, "user_id"); err != nil {
+		return err
+	}
+
+	return c.makeRequest(ctx, "DELETE", "/users/"+userID, nil, nil)
+}
+
+// GetUserStats retrieves user statistics
+func (c *LicenseChainClient) GetUserStats() (*UserStats, error) {
+	return c.GetUserStatsCtx(context.Background())
+}
+
+// GetUserStatsCtx retrieves user statistics using the provided context
+func (c *LicenseChainClient) GetUserStatsCtx(ctx context.Context) (*UserStats, error) {
+	var response struct {
+		Data UserStats `json:"data"`
+	}
+
+	err := c.makeRequest(ctx, "GET", "/users/stats", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+// ListUserLicenses lists the licenses owned by a user
+func (c *LicenseChainClient) ListUserLicenses(userID string, page, limit int) (*LicenseListResponse, error) {
+	return c.ListUserLicensesCtx(context.Background(), userID, page, limit)
+}
+
+// ListUserLicensesCtx lists the licenses owned by a user using the provided context
+func (c *LicenseChainClient) ListUserLicensesCtx(ctx context.Context, userID string, page, limit int) (*LicenseListResponse, error) {
+	if err := validateID(userID, "user_id"); err != nil {
+		return nil, err
+	}
+
+	var response LicenseListResponse
+	err := c.makeRequest(ctx, "GET", "/users/"+userID+"/licenses?"+paginationQuery(page, limit).Encode(), nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// Product Management
+
+// CreateProduct creates a new product
+func (c *LicenseChainClient) CreateProduct(req CreateProductRequest) (*Product, error) {
+	return c.CreateProductCtx(context.Background(), req)
+}
+
+// CreateProductCtx creates a new product using the provided context
+func (c *LicenseChainClient) CreateProductCtx(ctx context.Context, req CreateProductRequest) (*Product, error) {
+	if err := ValidateNotEmpty(req.Name, "name"); err != nil {
+		return nil, err
+	}
+	if !ValidateAmount(req.Price) {
+		return nil, NewValidationError("price must be a positive amount")
+	}
+	if !ValidateCurrency(req.Currency) {
+		return nil, NewValidationError(fmt.Sprintf("Unsupported currency: %s", req.Currency))
+	}
+
+	req.Currency = strings.ToUpper(req.Currency)
+	req.Metadata = SanitizeMetadata(req.Metadata)
+
+	var response struct {
+		Data Product `json:"data"`
+	}
+
+	err := c.makeRequest(ctx, "POST", "/products", req, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+// GetProduct retrieves a product by ID
+func (c *LicenseChainClient) GetProduct(productID string) (*Product, error) {
+	return c.GetProductCtx(context.Background(), productID)
+}
+
+// GetProductCtx retrieves a product by ID using the provided context
+func (c *LicenseChainClient) GetProductCtx(ctx context.Context, productID string) (*Product, error) {
+	if err := validateID(productID, "product_id"); err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data Product `json:"data"`
+	}
+
+	err := c.makeRequest(ctx, "GET", "/products/"+productID, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+// ListProducts lists products with pagination
+func (c *LicenseChainClient) ListProducts(page, limit int) (*ProductListResponse, error) {
+	return c.ListProductsCtx(context.Background(), page, limit)
+}
+
+// ListProductsCtx lists products with pagination using the provided context
+func (c *LicenseChainClient) ListProductsCtx(ctx context.Context, page, limit int) (*ProductListResponse, error) {
+	var response ProductListResponse
+	err := c.makeRequest(ctx, "GET", "/products?"+paginationQuery(page, limit).Encode(), nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// UpdateProduct updates a product
+func (c *LicenseChainClient) UpdateProduct(productID string, req UpdateProductRequest) (*Product, error) {
+	return c.UpdateProductCtx(context.Background(), productID, req)
+}
+
+// UpdateProductCtx updates a product using the provided context
+func (c *LicenseChainClient) UpdateProductCtx(ctx context.Context, productID string, req UpdateProductRequest) (*Product, error) {
+	if err := validateID(productID, "product_id"); err != nil {
+		return nil, err
+	}
+	if req.Price != 0 && !ValidateAmount(req.Price) {
+		return nil, NewValidationError("price must be a positive amount")
+	}
+	if req.Currency != "" {
+		if !ValidateCurrency(req.Currency) {
+			return nil, NewValidationError(fmt.Sprintf("Unsupported currency: %s", req.Currency))
+		}
+		req.Currency = strings.ToUpper(req.Currency)
+	}
+
+	if req.Metadata != nil {
+		req.Metadata = SanitizeMetadata(req.Metadata)
+	}
+
+	var response struct {
+		Data Product `json:"data"`
+	}
+
+	err := c.makeRequest(ctx, "PATCH", "/products/"+productID, req, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+// DeleteProduct deletes a product
+func (c *LicenseChainClient) DeleteProduct(productID string) error {
+	return c.DeleteProductCtx(context.Background(), productID)
+}
+
+// DeleteProductCtx deletes a product using the provided context
+func (c *LicenseChainClient) DeleteProductCtx(ctx context.Context, productID string) error {
+	if err := validateID(productID, "product_id"); err != nil {
+		return err
+	}
+
+	return c.makeRequest(ctx, "DELETE", "/products/"+productID, nil, nil)
+}
+
+// GetProductStats retrieves product statistics
+func (c *LicenseChainClient) GetProductStats() (*ProductStats, error) {
+	return c.GetProductStatsCtx(context.Background())
+}
+
+// GetProductStatsCtx retrieves product statistics using the provided context
+func (c *LicenseChainClient) GetProductStatsCtx(ctx context.Context) (*ProductStats, error) {
+	var response struct {
+		Data ProductStats `json:"data"`
+	}
+
+	err := c.makeRequest(ctx, "GET", "/products/stats", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+// ListProductLicenses lists the licenses issued for a product
+func (c *LicenseChainClient) ListProductLicenses(productID string, page, limit int) (*LicenseListResponse, error) {
+	return c.ListProductLicensesCtx(context.Background(), productID, page, limit)
+}
+
+// ListProductLicensesCtx lists the licenses issued for a product using the provided context
+func (c *LicenseChainClient) ListProductLicensesCtx(ctx context.Context, productID string, page, limit int) (*LicenseListResponse, error) {
+	if err := validateID(productID, "product_id"); err != nil {
+		return nil, err
+	}
+
+	var response LicenseListResponse
+	err := c.makeRequest(ctx, "GET", "/products/"+productID+"/licenses?"+paginationQuery(page, limit).Encode(), nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// Webhook Management
+
+// CreateWebhook registers a new webhook endpoint
+func (c *LicenseChainClient) CreateWebhook(req CreateWebhookRequest) (*Webhook, error) {
+	return c.CreateWebhookCtx(context.Background(), req)
+}
+
+// CreateWebhookCtx registers a new webhook endpoint using the provided context
+func (c *LicenseChainClient) CreateWebhookCtx(ctx context.Context, req CreateWebhookRequest) (*Webhook, error) {
+	if err := validateWebhookURL(req.URL); err != nil {
+		return nil, err
+	}
+	if len(req.Events) == 0 {
+		return nil, NewValidationError("events cannot be empty")
+	}
+	if err := validateWebhookEvents(req.Events); err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data Webhook `json:"data"`
+	}
+
+	err := c.makeRequest(ctx, "POST", "/webhooks", req, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+// ListWebhooks lists registered webhook endpoints with pagination
+func (c *LicenseChainClient) ListWebhooks(page, limit int) (*WebhookListResponse, error) {
+	return c.ListWebhooksCtx(context.Background(), page, limit)
+}
+
+// ListWebhooksCtx lists registered webhook endpoints with pagination using the provided context
+func (c *LicenseChainClient) ListWebhooksCtx(ctx context.Context, page, limit int) (*WebhookListResponse, error) {
+	var response WebhookListResponse
+	err := c.makeRequest(ctx, "GET", "/webhooks?"+paginationQuery(page, limit).Encode(), nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// GetWebhook retrieves a webhook endpoint by ID
+func (c *LicenseChainClient) GetWebhook(webhookID string) (*Webhook, error) {
+	return c.GetWebhookCtx(context.Background(), webhookID)
+}
+
+// GetWebhookCtx retrieves a webhook endpoint by ID using the provided context
+func (c *LicenseChainClient) GetWebhookCtx(ctx context.Context, webhookID string) (*Webhook, error) {
+	if err := validateID(webhookID, "webhook_id"); err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data Webhook `json:"data"`
+	}
+
+	err := c.makeRequest(ctx, "GET", "/webhooks/"+webhookID, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+// UpdateWebhook updates a webhook endpoint
+func (c *LicenseChainClient) UpdateWebhook(webhookID string, req UpdateWebhookRequest) (*Webhook, error) {
+	return c.UpdateWebhookCtx(context.Background(), webhookID, req)
+}
+
+// UpdateWebhookCtx updates a webhook endpoint using the provided context
+func (c *LicenseChainClient) UpdateWebhookCtx(ctx context.Context, webhookID string, req UpdateWebhookRequest) (*Webhook, error) {
+	if err := validateID(webhookID, "webhook_id"); err != nil {
+		return nil, err
+	}
+	if req.URL != "" {
+		if err := validateWebhookURL(req.URL); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateWebhookEvents(req.Events); err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data Webhook `json:"data"`
+	}
+
+	err := c.makeRequest(ctx, "PATCH", "/webhooks/"+webhookID, req, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+// DeleteWebhook deletes a webhook endpoint
+func (c *LicenseChainClient) DeleteWebhook(webhookID string) error {
+	return c.DeleteWebhookCtx(context.Background(), webhookID)
+}
+
+// DeleteWebhookCtx deletes a webhook endpoint using the provided context
+func (c *LicenseChainClient) DeleteWebhookCtx(ctx context.Context, webhookID string) error {
+	if err := validateID(webhookID, "webhook_id"); err != nil {
+		return err
+	}
+
+	return c.makeRequest(ctx, "DELETE", "/webhooks/"+webhookID, nil, nil)
+}
+
+// RotateWebhookSecret generates a new signing secret for a webhook endpoint.
+// The returned Webhook carries the new secret.
+func (c *LicenseChainClient) RotateWebhookSecret(webhookID string) (*Webhook, error) {
+	return c.RotateWebhookSecretCtx(context.Background(), webhookID)
+}
+
+// RotateWebhookSecretCtx generates a new signing secret for a webhook endpoint using the provided context
+func (c *LicenseChainClient) RotateWebhookSecretCtx(ctx context.Context, webhookID string) (*Webhook, error) {
+	if err := validateID(webhookID, "webhook_id"); err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data Webhook `json:"data"`
+	}
+
+	err := c.makeRequest(ctx, "POST", "/webhooks/"+webhookID+"/rotate-secret", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+// SendTestWebhook asks the API to deliver a test event to a webhook endpoint.
+// If eventType is empty the server chooses a sample event.
+func (c *LicenseChainClient) SendTestWebhook(webhookID, eventType string) (*WebhookTestResponse, error) {
+	return c.SendTestWebhookCtx(context.Background(), webhookID, eventType)
+}
+
+// SendTestWebhookCtx asks the API to deliver a test event to a webhook endpoint using the provided context
+func (c *LicenseChainClient) SendTestWebhookCtx(ctx context.Context, webhookID, eventType string) (*WebhookTestResponse, error) {
+	if err := validateID(webhookID, "webhook_id"); err != nil {
+		return nil, err
+	}
+
+	req := map[string]string{}
+	if eventType != "" {
+		if !ValidateWebhookEvent(eventType) {
+			return nil, NewValidationError(fmt.Sprintf("Unknown webhook event: %s", eventType))
+		}
+		req["event"] = eventType
+	}
+
+	var response struct {
+		Data WebhookTestResponse `json:"data"`
+	}
+
+	err := c.makeRequest(ctx, "POST", "/webhooks/"+webhookID+"/test", req, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+// ListEvents lists events that occurred in the account, oldest first unless
+// req.Order is "desc". Pass the returned NextCursor as After to fetch the
+// next page.
+func (c *LicenseChainClient) ListEvents(req ListEventsRequest) (*EventListResponse, error) {
+	return c.ListEventsCtx(context.Background(), req)
+}
+
+// ListEventsCtx lists events that occurred in the account using the provided context
+func (c *LicenseChainClient) ListEventsCtx(ctx context.Context, req ListEventsRequest) (*EventListResponse, error) {
+	if err := validateWebhookEvents(req.Types); err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	if req.After != "" {
+		query.Set("after", req.After)
+	}
+	if len(req.Types) > 0 {
+		query.Set("types", strings.Join(req.Types, ","))
+	}
+	if req.Limit > 0 {
+		_, limit := ValidatePagination(1, req.Limit)
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	if req.Order != "" {
+		if req.Order != "asc" && req.Order != "desc" {
+			return nil, NewValidationError(fmt.Sprintf("Invalid order: %s", req.Order))
+		}
+		query.Set("order", req.Order)
+	}
+
+	endpoint := "/events"
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var response EventListResponse
+	err := c.makeRequest(ctx, "GET", endpoint, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
 }
 
 // Health Check
 
 // Ping pings the API
 func (c *LicenseChainClient) Ping() (*PingResponse, error) {
+	return c.PingCtx(context.Background())
+}
+
+// PingCtx pings the API using the provided context
+func (c *LicenseChainClient) PingCtx(ctx context.Context) (*PingResponse, error) {
 	var response PingResponse
-	err := c.makeRequest("GET", "/ping", nil, &response)
+	err := c.makeRequest(ctx, "GET", "/ping", nil, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -142,8 +886,13 @@ func (c *LicenseChainClient) Ping() (*PingResponse, error) {
 
 // Health checks the API health
 func (c *LicenseChainClient) Health() (*HealthResponse, error) {
+	return c.HealthCtx(context.Background())
+}
+
+// HealthCtx checks the API health using the provided context
+func (c *LicenseChainClient) HealthCtx(ctx context.Context) (*HealthResponse, error) {
 	var response HealthResponse
-	err := c.makeRequest("GET", "/health", nil, &response)
+	err := c.makeRequest(ctx, "GET", "/health", nil, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -153,7 +902,87 @@ func (c *LicenseChainClient) Health() (*HealthResponse, error) {
 
 // Private methods
 
-func (c *LicenseChainClient) makeRequest(method, endpoint string, body interface{}, result interface{}) error {
+// validateID validates that an ID is present and a well-formed UUID
+func validateID(id, fieldName string) error {
+	if err := ValidateNotEmpty(id, fieldName); err != nil {
+		return err
+	}
+	if !ValidateUUID(id) {
+		return NewValidationError(fmt.Sprintf("Invalid %s format", fieldName))
+	}
+	return nil
+}
+
+// paginationQuery builds normalized page and limit query parameters
+func paginationQuery(page, limit int) url.Values {
+	page, limit = ValidatePagination(page, limit)
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("limit", strconv.Itoa(limit))
+	return query
+}
+
+// validateWebhookURL validates that a webhook URL is an absolute http(s) URL
+func validateWebhookURL(rawURL string) error {
+	if err := ValidateNotEmpty(rawURL, "url"); err != nil {
+		return err
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return NewValidationError("url must be an absolute http or https URL")
+	}
+	return nil
+}
+
+// validateWebhookEvents validates event names against WebhookEvents
+func validateWebhookEvents(events []string) error {
+	for _, event := range events {
+		if !ValidateWebhookEvent(event) {
+			return NewValidationError(fmt.Sprintf("Unknown webhook event: %s", event))
+		}
+	}
+	return nil
+}
+
+// validateLicense sends a validation request to the API
+func (c *LicenseChainClient) validateLicense(ctx context.Context, req ValidateLicenseRequest) (*ValidationResult, error) {
+	var response ValidationResult
+	err := c.makeRequest(withoutIdempotencyKey(ctx), "POST", "/licenses/validate", req, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Valid {
+		response.Reason = ValidationReasons.Valid
+	} else if response.Reason == "" {
+		response.Reason = ValidationReasons.Invalid
+	}
+	if response.ExpiresAt == nil && response.License != nil {
+		response.ExpiresAt = response.License.ExpiresAt
+	}
+
+	return &response, nil
+}
+
+// licenseAction performs a state transition such as revoke or renew on a license
+func (c *LicenseChainClient) licenseAction(ctx context.Context, licenseID, action string, body interface{}) (*License, error) {
+	if err := validateID(licenseID, "license_id"); err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data License `json:"data"`
+	}
+
+	err := c.makeRequest(ctx, "POST", "/licenses/"+licenseID+"/"+action, body, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+func (c *LicenseChainClient) makeRequest(ctx context.Context, method, endpoint string, body interface{}, result interface{}) error {
 	// Ensure endpoint starts with /v1 prefix
 	normalizedEndpoint := endpoint
 	if !strings.HasPrefix(endpoint, "/v1/") {
@@ -164,30 +993,58 @@ func (c *LicenseChainClient) makeRequest(method, endpoint string, body interface
 		}
 	}
 
-	var reqBody io.Reader
+	var jsonData []byte
 	if body != nil {
-		jsonData, err := json.Marshal(body)
+		var err error
+		jsonData, err = json.Marshal(body)
 		if err != nil {
 			return fmt.Errorf("failed to marshal request body: %v", err)
 		}
-		reqBody = bytes.NewBuffer(jsonData)
 	}
 
-	req, err := http.NewRequest(method, c.baseURL+normalizedEndpoint, reqBody)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %v", err)
+	// The idempotency key is chosen once per logical call and reused across
+	// retries, which also makes retrying a mutating request safe
+	retryPolicy := c.retryPolicy
+	var idempotencyKey string
+	if requiresIdempotencyKey(method) {
+		key, ok := IdempotencyKeyFromContext(ctx)
+		if !ok {
+			key = NewIdempotencyKey()
+		}
+		idempotencyKey = key
+		retryPolicy.RetryNonIdempotent = true
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-API-Version", "1.0")
-	req.Header.Set("X-Platform", "go-sdk")
-	req.Header.Set("User-Agent", "LicenseChain-Go-SDK/1.0.0")
+	return retryPolicy.Do(ctx, method, func() error {
+		// The request is rebuilt on every attempt so the body can be re-read
+		var reqBody io.Reader
+		if jsonData != nil {
+			reqBody = bytes.NewReader(jsonData)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, c.baseURL+normalizedEndpoint, reqBody)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %v", err)
+		}
+
+		for key, values := range c.headers {
+			req.Header[key] = values
+		}
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-API-Version", c.apiVersion)
+		req.Header.Set("X-Platform", "go-sdk")
+		req.Header.Set("User-Agent", c.userAgent)
+		if idempotencyKey != "" {
+			req.Header.Set(IdempotencyKeyHeader, idempotencyKey)
+		}
 
-	return RetryWithBackoff(func() error {
 		resp, err := c.client.Do(req)
 		if err != nil {
-			return err
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			return NewNetworkError(err)
 		}
 		defer resp.Body.Close()
 
@@ -196,36 +1053,27 @@ func (c *LicenseChainClient) makeRequest(method, endpoint string, body interface
 				if resp.ContentLength == 0 {
 					return nil
 				}
-				return json.NewDecoder(resp.Body).Decode(result)
+				if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+					return &LicenseChainError{
+						Type:      ErrInvalidResponse.Type,
+						Message:   fmt.Sprintf("Invalid response from server: %v", err),
+						Code:      resp.StatusCode,
+						RequestID: resp.Header.Get(RequestIDHeader),
+						Err:       err,
+					}
+				}
 			}
 			return nil
 		}
 
 		bodyBytes, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return NewHTTPError(resp.StatusCode, "Unknown error")
+			readErr := NewHTTPError(resp.StatusCode, "Unknown error")
+			readErr.RequestID = resp.Header.Get(RequestIDHeader)
+			readErr.Err = err
+			return readErr
 		}
 
-		var errorResp struct {
-			Error string `json:"error"`
-		}
-		if err := json.Unmarshal(bodyBytes, &errorResp); err != nil {
-			return NewHTTPError(resp.StatusCode, string(bodyBytes))
-		}
-
-		switch resp.StatusCode {
-		case 400:
-			return NewValidationError(errorResp.Error)
-		case 401, 403:
-			return NewAuthenticationError(errorResp.Error)
-		case 404:
-			return NewNotFoundError(errorResp.Error)
-		case 429:
-			return NewRateLimitError(errorResp.Error)
-		case 500, 502, 503, 504:
-			return NewServerError(errorResp.Error)
-		default:
-			return NewHTTPError(resp.StatusCode, errorResp.Error)
-		}
-	}, c.retries, time.Second)
-}
\ No newline at end of file
+		return newAPIError(resp, bodyBytes)
+	})
+}
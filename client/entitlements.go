@@ -0,0 +1,115 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Entitlements answers feature and quota questions about a validated
+// license. It is built from data already held locally, so it works equally
+// with a fresh ValidationResult, a cached one, or a License recovered by an
+// OfflineVerifier. A nil *Entitlements grants nothing.
+type Entitlements struct {
+	tier     string
+	features map[string]bool
+	quotas   map[string]int64
+}
+
+// NewEntitlements returns the entitlements granted by a license
+func NewEntitlements(license *License) *Entitlements {
+	e := &Entitlements{
+		features: make(map[string]bool),
+		quotas:   make(map[string]int64),
+	}
+	if license == nil {
+		return e
+	}
+
+	e.tier = license.Entitlements.Tier
+	for name, enabled := range license.Entitlements.Features {
+		e.features[name] = enabled
+	}
+	for name, quota := range license.Entitlements.Quotas {
+		e.quotas[name] = quota
+	}
+	return e
+}
+
+// Entitlements returns the entitlements granted by a validation result.
+// An invalid result grants nothing.
+func (r *ValidationResult) Entitlements() *Entitlements {
+	if r == nil || !r.Valid {
+		return NewEntitlements(nil)
+	}
+
+	e := NewEntitlements(r.License)
+	for _, name := range r.Features {
+		if _, ok := e.features[name]; !ok {
+			e.features[name] = true
+		}
+	}
+	return e
+}
+
+// Tier returns the license tier, e.g. "pro", or an empty string
+func (e *Entitlements) Tier() string {
+	if e == nil {
+		return ""
+	}
+	return e.tier
+}
+
+// HasFeature reports whether a boolean feature is enabled
+func (e *Entitlements) HasFeature(name string) bool {
+	if e == nil {
+		return false
+	}
+	return e.features[name]
+}
+
+// Features returns the names of all enabled features in sorted order
+func (e *Entitlements) Features() []string {
+	if e == nil {
+		return nil
+	}
+	names := make([]string, 0, len(e.features))
+	for name, enabled := range e.features {
+		if enabled {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Quota returns a numeric quota and whether the license defines it
+func (e *Entitlements) Quota(name string) (int64, bool) {
+	if e == nil {
+		return 0, false
+	}
+	quota, ok := e.quotas[name]
+	return quota, ok
+}
+
+// RequireFeature returns an error matching ErrFeatureNotEntitled unless the feature is enabled
+func (e *Entitlements) RequireFeature(name string) error {
+	if e.HasFeature(name) {
+		return nil
+	}
+	return &LicenseChainError{
+		Type:    ErrFeatureNotEntitled.Type,
+		Message: fmt.Sprintf("License does not include feature: %s", name),
+	}
+}
+
+// RequireQuota returns an error matching ErrFeatureNotEntitled unless the
+// quota is defined and at least amount
+func (e *Entitlements) RequireQuota(name string, amount int64) error {
+	if quota, ok := e.Quota(name); ok && quota >= amount {
+		return nil
+	}
+	return &LicenseChainError{
+		Type:    ErrFeatureNotEntitled.Type,
+		Message: fmt.Sprintf("License quota %s is below %d", name, amount),
+	}
+}
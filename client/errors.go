@@ -1,30 +1,90 @@
 package client
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// RequestIDHeader is the response header carrying the server-assigned request ID
+const RequestIDHeader = "X-Request-ID"
 
-// LicenseChainError represents an error from the LicenseChain API
+// LicenseChainError represents an error from the LicenseChain API.
+//
+// Errors can be matched against the Err* sentinels with errors.Is, which
+// compares the Type, and inspected with errors.As:
+//
+//	var lcErr *client.LicenseChainError
+//	if errors.Is(err, client.ErrNotFoundError) && errors.As(err, &lcErr) {
+//		log.Printf("not found (request %s)", lcErr.RequestID)
+//	}
 type LicenseChainError struct {
 	Type    string `json:"type"`
 	Message string `json:"message"`
-	Code    int    `json:"code,omitempty"`
+	// Code is the HTTP status code of the response, if there was one
+	Code int `json:"code,omitempty"`
+	// RequestID is the server-assigned request ID, useful when contacting support
+	RequestID string `json:"request_id,omitempty"`
+	// Fields lists field-level problems reported for validation errors
+	Fields []FieldError `json:"fields,omitempty"`
+	// Body is the raw response body
+	Body []byte `json:"-"`
+	// RetryAfter is the delay the server asked for before retrying, if any
+	RetryAfter time.Duration `json:"-"`
+	// Err is the underlying error, such as a network failure
+	Err error `json:"-"`
+}
+
+// FieldError describes a problem with a single request field
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 func (e *LicenseChainError) Error() string {
 	return e.Message
 }
 
+// Is reports whether target is a *LicenseChainError of the same Type, so
+// that errors.Is(err, ErrNotFoundError) matches any not-found error
+func (e *LicenseChainError) Is(target error) bool {
+	t, ok := target.(*LicenseChainError)
+	if !ok {
+		return false
+	}
+	return e.Type == t.Type
+}
+
+// Unwrap returns the underlying error
+func (e *LicenseChainError) Unwrap() error {
+	return e.Err
+}
+
 // Error types
 var (
-	ErrInvalidAPIKey      = &LicenseChainError{Type: "invalid_api_key", Message: "Invalid API key provided"}
-	ErrInvalidURL         = &LicenseChainError{Type: "invalid_url", Message: "Invalid URL"}
-	ErrInvalidResponse    = &LicenseChainError{Type: "invalid_response", Message: "Invalid response from server"}
-	ErrNetworkError       = &LicenseChainError{Type: "network_error", Message: "Network error occurred"}
-	ErrValidationError    = &LicenseChainError{Type: "validation_error", Message: "Validation error"}
+	ErrInvalidAPIKey       = &LicenseChainError{Type: "invalid_api_key", Message: "Invalid API key provided"}
+	ErrInvalidURL          = &LicenseChainError{Type: "invalid_url", Message: "Invalid URL"}
+	ErrInvalidResponse     = &LicenseChainError{Type: "invalid_response", Message: "Invalid response from server"}
+	ErrNetworkError        = &LicenseChainError{Type: "network_error", Message: "Network error occurred"}
+	ErrValidationError     = &LicenseChainError{Type: "validation_error", Message: "Validation error"}
 	ErrAuthenticationError = &LicenseChainError{Type: "authentication_error", Message: "Authentication error"}
-	ErrNotFoundError      = &LicenseChainError{Type: "not_found_error", Message: "Resource not found"}
-	ErrRateLimitError     = &LicenseChainError{Type: "rate_limit_error", Message: "Rate limit exceeded"}
-	ErrServerError        = &LicenseChainError{Type: "server_error", Message: "Server error"}
-	ErrUnknownError       = &LicenseChainError{Type: "unknown_error", Message: "Unknown error occurred"}
+	ErrNotFoundError       = &LicenseChainError{Type: "not_found_error", Message: "Resource not found"}
+	ErrRateLimitError      = &LicenseChainError{Type: "rate_limit_error", Message: "Rate limit exceeded"}
+	ErrServerError         = &LicenseChainError{Type: "server_error", Message: "Server error"}
+	ErrHTTPError           = &LicenseChainError{Type: "http_error", Message: "HTTP error"}
+	ErrUnknownError        = &LicenseChainError{Type: "unknown_error", Message: "Unknown error occurred"}
+	ErrInvalidToken        = &LicenseChainError{Type: "invalid_token", Message: "Invalid license token"}
+	ErrLicenseExpired      = &LicenseChainError{Type: "license_expired", Message: "License has expired"}
+	ErrLicenseInactive     = &LicenseChainError{Type: "license_inactive", Message: "License is not active"}
+	ErrProductMismatch     = &LicenseChainError{Type: "product_mismatch", Message: "License is not valid for this product"}
+	ErrSeatLimitReached    = &LicenseChainError{Type: "seat_limit_reached", Message: "License seat limit reached"}
+	ErrHardwareMismatch    = &LicenseChainError{Type: "hardware_mismatch", Message: "License is not bound to this device"}
+	ErrFeatureNotEntitled  = &LicenseChainError{Type: "feature_not_entitled", Message: "License does not include this feature"}
+	ErrCacheTampered       = &LicenseChainError{Type: "cache_tampered", Message: "Cache entry failed integrity check"}
+	ErrWebhookQueueFull    = &LicenseChainError{Type: "webhook_queue_full", Message: "Webhook processing queue is full"}
+	ErrWebhookClosed       = &LicenseChainError{Type: "webhook_closed", Message: "Webhook handler is shut down"}
+	ErrWebhookInProgress   = &LicenseChainError{Type: "webhook_in_progress", Message: "Webhook event is already being processed"}
 )
 
 // NewValidationError creates a new validation error
@@ -75,3 +135,82 @@ func NewHTTPError(statusCode int, message string) *LicenseChainError {
 		Code:    statusCode,
 	}
 }
+
+// NewNetworkError creates a new network error wrapping err
+func NewNetworkError(err error) *LicenseChainError {
+	return &LicenseChainError{
+		Type:    "network_error",
+		Message: fmt.Sprintf("Network error: %v", err),
+		Err:     err,
+	}
+}
+
+// apiErrorBody is the error envelope returned by the API
+type apiErrorBody struct {
+	Error     string       `json:"error"`
+	Code      string       `json:"code"`
+	Message   string       `json:"message"`
+	RequestID string       `json:"request_id"`
+	Fields    []FieldError `json:"fields"`
+	Errors    []FieldError `json:"errors"`
+}
+
+// apiErrorCodes maps machine-readable API error codes to the error types they produce
+var apiErrorCodes = map[string]*LicenseChainError{
+	"seat_limit_reached": ErrSeatLimitReached,
+	"license_expired":    ErrLicenseExpired,
+	"license_inactive":   ErrLicenseInactive,
+	"product_mismatch":   ErrProductMismatch,
+	"hardware_mismatch":  ErrHardwareMismatch,
+}
+
+// newAPIError builds a LicenseChainError from a non-2xx response and its body
+func newAPIError(resp *http.Response, body []byte) *LicenseChainError {
+	var parsed apiErrorBody
+	message := string(body)
+	parsedOK := json.Unmarshal(body, &parsed) == nil
+	if parsedOK {
+		message = parsed.Error
+		if message == "" {
+			message = parsed.Message
+		}
+	}
+
+	var lcErr *LicenseChainError
+	switch {
+	case !parsedOK:
+		lcErr = NewHTTPError(resp.StatusCode, message)
+	case resp.StatusCode == 400 || resp.StatusCode == 422:
+		lcErr = NewValidationError(message)
+	case resp.StatusCode == 401 || resp.StatusCode == 403:
+		lcErr = NewAuthenticationError(message)
+	case resp.StatusCode == 404:
+		lcErr = NewNotFoundError(message)
+	case resp.StatusCode == 429:
+		lcErr = NewRateLimitError(message)
+	case resp.StatusCode >= 500:
+		lcErr = NewServerError(message)
+	default:
+		lcErr = NewHTTPError(resp.StatusCode, message)
+	}
+
+	if sentinel, ok := apiErrorCodes[parsed.Code]; ok {
+		lcErr.Type = sentinel.Type
+		lcErr.Message = fmt.Sprintf("%s: %s", sentinel.Message, message)
+	}
+
+	lcErr.Code = resp.StatusCode
+	lcErr.Body = body
+	lcErr.RequestID = resp.Header.Get(RequestIDHeader)
+	if lcErr.RequestID == "" {
+		lcErr.RequestID = parsed.RequestID
+	}
+	lcErr.Fields = parsed.Fields
+	if len(lcErr.Fields) == 0 {
+		lcErr.Fields = parsed.Errors
+	}
+	if resp.StatusCode == 429 || resp.StatusCode == 503 {
+		lcErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+	}
+	return lcErr
+}
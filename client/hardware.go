@@ -0,0 +1,324 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Hardware fingerprint components
+const (
+	HardwareComponentMachineID = "machine_id"
+	HardwareComponentMAC       = "mac"
+	HardwareComponentCPU       = "cpu"
+	HardwareComponentDisk      = "disk"
+)
+
+// DefaultHardwareComponents are the components collected when none are configured
+var DefaultHardwareComponents = []string{
+	HardwareComponentMachineID,
+	HardwareComponentMAC,
+	HardwareComponentCPU,
+	HardwareComponentDisk,
+}
+
+// HardwareFingerprint identifies a device by hashes of its hardware components
+type HardwareFingerprint struct {
+	// ID is a stable hash over all collected components
+	ID string `json:"hardware_id"`
+	// Components maps each collected component to its individual hash
+	Components map[string]string `json:"components"`
+}
+
+// FingerprintOptions configures hardware fingerprint collection
+type FingerprintOptions struct {
+	// Components lists the components to collect; defaults to DefaultHardwareComponents
+	Components []string
+	// Salt is mixed into every hash so fingerprints differ between applications
+	Salt string
+}
+
+// GetHardwareFingerprint collects a fingerprint of the current machine.
+// Components that cannot be read on this platform are skipped; an error is
+// returned only if none could be collected.
+func GetHardwareFingerprint(opts FingerprintOptions) (*HardwareFingerprint, error) {
+	components := opts.Components
+	if len(components) == 0 {
+		components = DefaultHardwareComponents
+	}
+
+	fp := &HardwareFingerprint{Components: make(map[string]string)}
+	for _, component := range components {
+		value := collectHardwareComponent(component)
+		if value == "" {
+			continue
+		}
+		fp.Components[component] = SHA256(opts.Salt + ":" + component + ":" + value)
+	}
+	if len(fp.Components) == 0 {
+		return nil, NewValidationError("no hardware components could be collected")
+	}
+
+	fp.ID = combineComponentHashes(fp.Components)
+	return fp, nil
+}
+
+// GetHardwareID returns the default hardware ID of the current machine, or
+// an empty string if no hardware components could be collected
+func GetHardwareID() string {
+	fp, err := GetHardwareFingerprint(FingerprintOptions{})
+	if err != nil {
+		return ""
+	}
+	return fp.ID
+}
+
+// MatchingComponents returns how many components are present in both fingerprints with equal hashes
+func (f *HardwareFingerprint) MatchingComponents(other *HardwareFingerprint) int {
+	if f == nil || other == nil {
+		return 0
+	}
+	matches := 0
+	for component, hash := range f.Components {
+		if other.Components[component] == hash {
+			matches++
+		}
+	}
+	return matches
+}
+
+// Matches reports whether other identifies the same device, tolerating up to
+// maxChanged components that differ or are missing, e.g. after a NIC or disk
+// replacement
+func (f *HardwareFingerprint) Matches(other *HardwareFingerprint, maxChanged int) bool {
+	if f == nil || other == nil {
+		return false
+	}
+	if f.ID == other.ID {
+		return true
+	}
+	total := len(f.Components)
+	if len(other.Components) > total {
+		total = len(other.Components)
+	}
+	return total-f.MatchingComponents(other) <= maxChanged
+}
+
+// combineComponentHashes hashes component hashes in a stable order
+func combineComponentHashes(components map[string]string) string {
+	names := make([]string, 0, len(components))
+	for name := range components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	h := sha256.New()
+	for _, name := range names {
+		h.Write([]byte(name + "=" + components[name] + "\n"))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func collectHardwareComponent(component string) string {
+	switch component {
+	case HardwareComponentMachineID:
+		return readMachineID()
+	case HardwareComponentMAC:
+		return readMACAddresses()
+	case HardwareComponentCPU:
+		return readCPUInfo()
+	case HardwareComponentDisk:
+		return readDiskSerials()
+	}
+	return ""
+}
+
+// readMachineID returns the OS installation ID: /etc/machine-id on Linux,
+// the MachineGuid registry value on Windows and IOPlatformUUID on macOS
+func readMachineID() string {
+	for _, path := range []string{"/etc/machine-id", "/var/lib/dbus/machine-id"} {
+		if data, err := os.ReadFile(path); err == nil {
+			if id := strings.TrimSpace(string(data)); id != "" {
+				return id
+			}
+		}
+	}
+	return platformMachineID()
+}
+
+// readMACAddresses returns the sorted MAC addresses of physical interfaces
+func readMACAddresses() string {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return ""
+	}
+
+	var macs []string
+	for _, iface := range interfaces {
+		if iface.Flags&net.FlagLoopback != 0 || len(iface.HardwareAddr) == 0 {
+			continue
+		}
+		if isVirtualInterface(iface.Name) {
+			continue
+		}
+		macs = append(macs, iface.HardwareAddr.String())
+	}
+	sort.Strings(macs)
+	return strings.Join(macs, ",")
+}
+
+func isVirtualInterface(name string) bool {
+	for _, prefix := range []string{"docker", "veth", "br-", "virbr", "vmnet", "tun", "tap", "wg", "utun"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// readCPUInfo returns the CPU vendor and model from /proc/cpuinfo
+func readCPUInfo() string {
+	file, err := os.Open("/proc/cpuinfo")
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	var vendor, model string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() && (vendor == "" || model == "") {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "vendor_id":
+			vendor = strings.TrimSpace(value)
+		case "model name":
+			model = strings.TrimSpace(value)
+		}
+	}
+	if vendor == "" && model == "" {
+		return ""
+	}
+	return vendor + "|" + model
+}
+
+// readDiskSerials returns the sorted serial numbers of block devices
+func readDiskSerials() string {
+	paths, err := filepath.Glob("/sys/block/*/device/serial")
+	if err != nil {
+		return ""
+	}
+
+	var serials []string
+	for _, path := range paths {
+		device := filepath.Base(filepath.Dir(filepath.Dir(path)))
+		if strings.HasPrefix(device, "loop") || strings.HasPrefix(device, "ram") || strings.HasPrefix(device, "dm-") {
+			continue
+		}
+		if data, err := os.ReadFile(path); err == nil {
+			if serial := strings.TrimSpace(string(data)); serial != "" {
+				serials = append(serials, serial)
+			}
+		}
+	}
+	sort.Strings(serials)
+	return strings.Join(serials, ",")
+}
+
+// Hardware Binding
+
+// BindHardwareID node-locks a license to the device identified by fp
+func (c *LicenseChainClient) BindHardwareID(licenseKey string, fp *HardwareFingerprint) (*License, error) {
+	return c.BindHardwareIDCtx(context.Background(), licenseKey, fp)
+}
+
+// BindHardwareIDCtx node-locks a license to the device identified by fp using the provided context
+func (c *LicenseChainClient) BindHardwareIDCtx(ctx context.Context, licenseKey string, fp *HardwareFingerprint) (*License, error) {
+	req, err := newHardwareRequest(licenseKey, fp)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data License `json:"data"`
+	}
+
+	err = c.makeRequest(ctx, "POST", "/licenses/hardware/bind", req, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+// ValidateHardwareID checks whether a license is bound to the device identified by fp.
+// The server compares individual components, so a device with a single replaced component still matches.
+func (c *LicenseChainClient) ValidateHardwareID(licenseKey string, fp *HardwareFingerprint) (bool, error) {
+	return c.ValidateHardwareIDCtx(context.Background(), licenseKey, fp)
+}
+
+// ValidateHardwareIDCtx checks whether a license is bound to the device identified by fp using the provided context
+func (c *LicenseChainClient) ValidateHardwareIDCtx(ctx context.Context, licenseKey string, fp *HardwareFingerprint) (bool, error) {
+	req, err := newHardwareRequest(licenseKey, fp)
+	if err != nil {
+		return false, err
+	}
+
+	var response struct {
+		Valid bool `json:"valid"`
+	}
+
+	err = c.makeRequest(withoutIdempotencyKey(ctx), "POST", "/licenses/hardware/validate", req, &response)
+	if err != nil {
+		return false, err
+	}
+
+	return response.Valid, nil
+}
+
+// UnbindHardwareID releases a license from the device identified by fp
+func (c *LicenseChainClient) UnbindHardwareID(licenseKey string, fp *HardwareFingerprint) error {
+	return c.UnbindHardwareIDCtx(context.Background(), licenseKey, fp)
+}
+
+// UnbindHardwareIDCtx releases a license from the device identified by fp using the provided context
+func (c *LicenseChainClient) UnbindHardwareIDCtx(ctx context.Context, licenseKey string, fp *HardwareFingerprint) error {
+	req, err := newHardwareRequest(licenseKey, fp)
+	if err != nil {
+		return err
+	}
+
+	return c.makeRequest(ctx, "POST", "/licenses/hardware/unbind", req, nil)
+}
+
+// hardwareRequest is the request body for hardware binding endpoints
+type hardwareRequest struct {
+	LicenseKey string            `json:"license_key"`
+	HardwareID string            `json:"hardware_id"`
+	Components map[string]string `json:"components,omitempty"`
+}
+
+func newHardwareRequest(licenseKey string, fp *HardwareFingerprint) (*hardwareRequest, error) {
+	if err := ValidateNotEmpty(licenseKey, "license_key"); err != nil {
+		return nil, err
+	}
+	if fp == nil {
+		return nil, NewValidationError("hardware fingerprint cannot be nil")
+	}
+	if err := ValidateNotEmpty(fp.ID, "hardware_id"); err != nil {
+		return nil, err
+	}
+	return &hardwareRequest{
+		LicenseKey: licenseKey,
+		HardwareID: fp.ID,
+		Components: fp.Components,
+	}, nil
+}
@@ -0,0 +1,23 @@
+//go:build darwin
+
+package client
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// platformMachineID returns the IOPlatformUUID of the Mac's logic board
+func platformMachineID() string {
+	out, err := exec.Command("/usr/sbin/ioreg", "-rd1", "-c", "IOPlatformExpertDevice").Output()
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		key, value, ok := strings.Cut(line, "=")
+		if ok && strings.TrimSpace(key) == `"IOPlatformUUID"` {
+			return strings.Trim(strings.TrimSpace(value), `"`)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,9 @@
+//go:build !windows && !darwin
+
+package client
+
+// platformMachineID returns an empty string; the machine ID is read from
+// /etc/machine-id on these platforms
+func platformMachineID() string {
+	return ""
+}
@@ -0,0 +1,26 @@
+//go:build windows
+
+package client
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// platformMachineID returns the MachineGuid registry value set at Windows installation
+func platformMachineID() string {
+	reg := filepath.Join(os.Getenv("SystemRoot"), "System32", "reg.exe")
+	out, err := exec.Command(reg, "query", `HKLM\SOFTWARE\Microsoft\Cryptography`, "/v", "MachineGuid", "/reg:64").Output()
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 3 && fields[0] == "MachineGuid" {
+			return fields[2]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+)
+
+// IdempotencyKeyHeader is the header carrying the idempotency key of a mutating request
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey returns a context that makes mutating client calls use
+// key as their Idempotency-Key instead of a generated one. Reusing the same
+// key when replaying a call (e.g. after a crash) lets the server return the
+// original result instead of performing the operation twice.
+//
+// Every mutating call made with the returned context sends key, so pass it
+// to a single call only and derive a new context for the next operation.
+// Read-style calls sent as POST, such as ValidateLicense, FetchLicenseToken,
+// ValidateHardwareID and lease heartbeats, ignore it.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// withoutIdempotencyKey returns a context in which no caller-supplied
+// idempotency key is set, for requests that must not be deduplicated
+func withoutIdempotencyKey(ctx context.Context) context.Context {
+	if _, ok := IdempotencyKeyFromContext(ctx); !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, "")
+}
+
+// IdempotencyKeyFromContext returns the idempotency key set with WithIdempotencyKey
+func IdempotencyKeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key, ok && key != ""
+}
+
+// NewIdempotencyKey generates a random idempotency key (a version 4 UUID)
+func NewIdempotencyKey() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		// crypto/rand only fails if the OS entropy source is unavailable
+		panic(fmt.Sprintf("licensechain: failed to generate idempotency key: %v", err))
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// requiresIdempotencyKey reports whether requests with the given method carry an idempotency key
+func requiresIdempotencyKey(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
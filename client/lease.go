@@ -0,0 +1,333 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Lease Management
+
+// CheckoutLease checks out a floating seat of a license for the given device
+func (c *LicenseChainClient) CheckoutLease(licenseKey, deviceID string) (*Lease, error) {
+	return c.CheckoutLeaseCtx(context.Background(), licenseKey, deviceID)
+}
+
+// CheckoutLeaseCtx checks out a floating seat of a license for the given device using the provided context
+func (c *LicenseChainClient) CheckoutLeaseCtx(ctx context.Context, licenseKey, deviceID string) (*Lease, error) {
+	if err := ValidateNotEmpty(licenseKey, "license_key"); err != nil {
+		return nil, err
+	}
+	if err := ValidateNotEmpty(deviceID, "device_id"); err != nil {
+		return nil, err
+	}
+
+	req := map[string]string{"license_key": licenseKey, "device_id": deviceID}
+	var response struct {
+		Data Lease `json:"data"`
+	}
+
+	err := c.makeRequest(ctx, "POST", "/licenses/leases", req, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+// RenewLease extends a lease with a heartbeat
+func (c *LicenseChainClient) RenewLease(leaseID string) (*Lease, error) {
+	return c.RenewLeaseCtx(context.Background(), leaseID)
+}
+
+// RenewLeaseCtx extends a lease with a heartbeat using the provided context
+func (c *LicenseChainClient) RenewLeaseCtx(ctx context.Context, leaseID string) (*Lease, error) {
+	if err := ValidateNotEmpty(leaseID, "lease_id"); err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data Lease `json:"data"`
+	}
+
+	err := c.makeRequest(withoutIdempotencyKey(ctx), "POST", "/licenses/leases/"+url.PathEscape(leaseID)+"/heartbeat", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+// ReleaseLease returns a lease's seat to the pool
+func (c *LicenseChainClient) ReleaseLease(leaseID string) error {
+	return c.ReleaseLeaseCtx(context.Background(), leaseID)
+}
+
+// ReleaseLeaseCtx returns a lease's seat to the pool using the provided context
+func (c *LicenseChainClient) ReleaseLeaseCtx(ctx context.Context, leaseID string) error {
+	if err := ValidateNotEmpty(leaseID, "lease_id"); err != nil {
+		return err
+	}
+
+	return c.makeRequest(ctx, "DELETE", "/licenses/leases/"+url.PathEscape(leaseID), nil, nil)
+}
+
+// LeaseManagerConfig configures a LeaseManager
+type LeaseManagerConfig struct {
+	LicenseKey string
+	DeviceID   string
+	// HeartbeatInterval is the time between heartbeats; defaults to half the
+	// lease's renewal interval, or 30 seconds if the server does not send one
+	HeartbeatInterval time.Duration
+	// GracePeriod is how long heartbeats may keep failing with transient
+	// errors before the lease is declared lost; defaults to 2 minutes. The
+	// lease is always declared lost once the server-side lease expires.
+	GracePeriod time.Duration
+	// OnLost is called once, in a new goroutine, when the lease is lost. It
+	// may call Close.
+	OnLost func(error)
+}
+
+// LeaseManager holds a floating license seat for the lifetime of a process.
+// It checks out a lease on Start, renews it with periodic heartbeats, and
+// releases it on Close. Heartbeats go through the client's retry policy;
+// transient failures are tolerated for the configured grace period, while
+// errors such as a revoked license end the lease immediately.
+type LeaseManager struct {
+	client *LicenseChainClient
+	config LeaseManagerConfig
+
+	mu        sync.Mutex
+	lease     *Lease
+	lastRenew time.Time
+	started   bool
+	closed    bool
+	isLost    bool
+
+	lost      chan error
+	lostOnce  sync.Once
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewLeaseManager creates a lease manager; call Start to check out the lease
+func NewLeaseManager(c *LicenseChainClient, config LeaseManagerConfig) *LeaseManager {
+	if config.GracePeriod <= 0 {
+		config.GracePeriod = 2 * time.Minute
+	}
+	return &LeaseManager{
+		client: c,
+		config: config,
+		lost:   make(chan error, 1),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+}
+
+// Start checks out the lease and starts sending heartbeats in the background.
+// It may be called again only after a failed checkout.
+func (m *LeaseManager) Start(ctx context.Context) error {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return NewValidationError("lease manager is closed")
+	}
+	if m.started {
+		m.mu.Unlock()
+		return NewValidationError("lease manager already started")
+	}
+	m.started = true
+	m.mu.Unlock()
+
+	lease, err := m.client.CheckoutLeaseCtx(ctx, m.config.LicenseKey, m.config.DeviceID)
+	if err != nil {
+		m.mu.Lock()
+		m.started = false
+		m.mu.Unlock()
+		return err
+	}
+
+	m.mu.Lock()
+	if m.closed {
+		// Close ran during the checkout and will not release this lease
+		m.mu.Unlock()
+		releaseCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = m.client.ReleaseLeaseCtx(releaseCtx, lease.ID)
+		return NewValidationError("lease manager is closed")
+	}
+	m.lease = lease
+	m.lastRenew = time.Now()
+	m.mu.Unlock()
+
+	go m.heartbeat(m.heartbeatInterval(lease))
+	return nil
+}
+
+// Lease returns the current lease, or nil before Start
+func (m *LeaseManager) Lease() *Lease {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.lease == nil {
+		return nil
+	}
+	lease := *m.lease
+	return &lease
+}
+
+// Lost returns a channel that receives the reason once the lease is lost
+func (m *LeaseManager) Lost() <-chan error {
+	return m.lost
+}
+
+// Close stops the heartbeats and releases the lease
+func (m *LeaseManager) Close() error {
+	var err error
+	m.closeOnce.Do(func() {
+		m.mu.Lock()
+		m.closed = true
+		m.mu.Unlock()
+
+		lease := m.Lease()
+		if lease == nil {
+			return
+		}
+
+		close(m.stop)
+		<-m.done
+
+		m.mu.Lock()
+		isLost := m.isLost
+		m.mu.Unlock()
+		if isLost {
+			// The lease is already gone server-side
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		err = m.client.ReleaseLeaseCtx(ctx, lease.ID)
+	})
+	return err
+}
+
+func (m *LeaseManager) heartbeatInterval(lease *Lease) time.Duration {
+	if m.config.HeartbeatInterval > 0 {
+		return m.config.HeartbeatInterval
+	}
+	if lease.RenewIntervalSeconds > 0 {
+		return time.Duration(lease.RenewIntervalSeconds) * time.Second / 2
+	}
+	return 30 * time.Second
+}
+
+func (m *LeaseManager) heartbeat(interval time.Duration) {
+	defer close(m.done)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-m.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	deadline := time.NewTimer(time.Until(m.lostDeadline()))
+	defer deadline.Stop()
+
+	var lastErr error
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-deadline.C:
+			m.markLost(m.deadlineError(lastErr))
+			return
+		case <-ticker.C:
+		}
+
+		// A renewal, including the client's retries, may not outlast the
+		// lease, so the loss is reported on time while the API hangs
+		leaseID := m.Lease().ID
+		renewCtx, renewCancel := context.WithDeadline(ctx, m.lostDeadline())
+		lease, err := m.client.RenewLeaseCtx(renewCtx, leaseID)
+		renewCancel()
+		if err == nil {
+			m.mu.Lock()
+			m.lease = lease
+			m.lastRenew = time.Now()
+			m.mu.Unlock()
+
+			lastErr = nil
+			if !deadline.Stop() {
+				<-deadline.C
+			}
+			deadline.Reset(time.Until(m.lostDeadline()))
+			continue
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if renewCtx.Err() != nil {
+			m.markLost(m.deadlineError(lastErr))
+			return
+		}
+
+		if !isTransientError(err) {
+			m.markLost(fmt.Errorf("lease %s lost: %w", leaseID, err))
+			return
+		}
+		lastErr = err
+		if !time.Now().Before(m.lostDeadline()) {
+			m.markLost(m.deadlineError(err))
+			return
+		}
+	}
+}
+
+// lostDeadline returns when the lease is lost without a successful
+// heartbeat: after the grace period, or when the server-side lease
+// expires if that is sooner
+func (m *LeaseManager) lostDeadline() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	deadline := m.lastRenew.Add(m.config.GracePeriod)
+	if !m.lease.ExpiresAt.IsZero() && m.lease.ExpiresAt.Before(deadline) {
+		deadline = m.lease.ExpiresAt
+	}
+	return deadline
+}
+
+// deadlineError describes a lease lost at its deadline; err is the last heartbeat failure, if any
+func (m *LeaseManager) deadlineError(err error) error {
+	deadline := m.lostDeadline()
+	leaseID := m.Lease().ID
+
+	if err == nil {
+		return fmt.Errorf("lease %s lost: no successful heartbeat before %s", leaseID, deadline.Format(time.RFC3339))
+	}
+	return fmt.Errorf("lease %s lost: no successful heartbeat before %s: %w", leaseID, deadline.Format(time.RFC3339), err)
+}
+
+func (m *LeaseManager) markLost(err error) {
+	m.lostOnce.Do(func() {
+		m.mu.Lock()
+		m.isLost = true
+		m.mu.Unlock()
+
+		m.lost <- err
+		if m.config.OnLost != nil {
+			// In its own goroutine, so OnLost may call Close, which waits
+			// for the heartbeat goroutine to exit
+			go m.config.OnLost(err)
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newLeaseServer serves lease checkouts expiring after ttl and answers
+// heartbeats with heartbeat; it counts lease releases
+func newLeaseServer(t *testing.T, ttl time.Duration, heartbeat http.HandlerFunc) (*LicenseChainClient, *atomic.Int32) {
+	var releases atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/heartbeat"):
+			heartbeat(w, r)
+		case r.Method == http.MethodDelete:
+			releases.Add(1)
+		default:
+			fmt.Fprintf(w, `{"data":{"id":"lease_1","expires_at":%q}}`, time.Now().Add(ttl).Format(time.RFC3339Nano))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return NewClient("key", WithBaseURL(srv.URL)), &releases
+}
+
+func TestLeaseManagerRenewsAndReleases(t *testing.T) {
+	lc, releases := newLeaseServer(t, time.Minute, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"data":{"id":"lease_1","expires_at":%q}}`, time.Now().Add(time.Minute).Format(time.RFC3339Nano))
+	})
+	m := NewLeaseManager(lc, LeaseManagerConfig{LicenseKey: "LICENSE", DeviceID: "device", HeartbeatInterval: 20 * time.Millisecond})
+
+	require.NoError(t, m.Start(context.Background()))
+	assert.Error(t, m.Start(context.Background()), "a second Start must fail")
+
+	time.Sleep(100 * time.Millisecond)
+	select {
+	case err := <-m.Lost():
+		t.Fatalf("lease lost: %v", err)
+	default:
+	}
+
+	require.NoError(t, m.Close())
+	assert.Equal(t, int32(1), releases.Load())
+	assert.Error(t, m.Start(context.Background()), "Start after Close must fail")
+}
+
+func TestLeaseManagerLostAtServerExpiryWhileRenewHangs(t *testing.T) {
+	lc, _ := newLeaseServer(t, 300*time.Millisecond, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(5 * time.Second):
+		case <-r.Context().Done():
+		}
+	})
+	m := NewLeaseManager(lc, LeaseManagerConfig{LicenseKey: "LICENSE", DeviceID: "device", HeartbeatInterval: 100 * time.Millisecond})
+
+	start := time.Now()
+	require.NoError(t, m.Start(context.Background()))
+	defer m.Close()
+
+	select {
+	case err := <-m.Lost():
+		assert.Contains(t, err.Error(), "lease_1 lost")
+		assert.Less(t, time.Since(start), time.Second)
+	case <-time.After(3 * time.Second):
+		t.Fatal("lease was not declared lost at server expiry")
+	}
+}
+
+func TestLeaseManagerOnLostMayClose(t *testing.T) {
+	lc, releases := newLeaseServer(t, time.Minute, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	closed := make(chan error, 1)
+	var m *LeaseManager
+	m = NewLeaseManager(lc, LeaseManagerConfig{
+		LicenseKey:        "LICENSE",
+		DeviceID:          "device",
+		HeartbeatInterval: 20 * time.Millisecond,
+		OnLost: func(error) {
+			closed <- m.Close()
+		},
+	})
+	require.NoError(t, m.Start(context.Background()))
+
+	select {
+	case err := <-closed:
+		assert.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Close called from OnLost did not return")
+	}
+	assert.Equal(t, int32(0), releases.Load(), "a lost lease is not released")
+}
@@ -1,6 +1,10 @@
 package client
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
 
 // License represents a license in the LicenseChain system
 type License struct {
@@ -12,14 +16,28 @@ type License struct {
 	CreatedAt time.Time              `json:"created_at"`
 	UpdatedAt time.Time              `json:"updated_at"`
 	ExpiresAt *time.Time             `json:"expires_at,omitempty"`
+	MaxSeats  int                    `json:"max_seats,omitempty"`
+	UsedSeats int                    `json:"used_seats,omitempty"`
 	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+
+	Entitlements LicenseEntitlements `json:"entitlements"`
+}
+
+// LicenseEntitlements represents what a license grants
+type LicenseEntitlements struct {
+	Tier     string           `json:"tier,omitempty"`
+	Features map[string]bool  `json:"features,omitempty"`
+	Quotas   map[string]int64 `json:"quotas,omitempty"`
 }
 
 // CreateLicenseRequest represents a request to create a license
 type CreateLicenseRequest struct {
 	UserID    string                 `json:"user_id"`
 	ProductID string                 `json:"product_id"`
+	MaxSeats  int                    `json:"max_seats,omitempty"`
 	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+
+	Entitlements *LicenseEntitlements `json:"entitlements,omitempty"`
 }
 
 // UpdateLicenseRequest represents a request to update a license
@@ -27,6 +45,17 @@ type UpdateLicenseRequest struct {
 	Status    string                 `json:"status,omitempty"`
 	ExpiresAt *time.Time             `json:"expires_at,omitempty"`
 	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+
+	Entitlements *LicenseEntitlements `json:"entitlements,omitempty"`
+}
+
+// ListLicensesRequest represents pagination and filters for listing licenses
+type ListLicensesRequest struct {
+	Page      int    `json:"page,omitempty"`
+	Limit     int    `json:"limit,omitempty"`
+	Status    string `json:"status,omitempty"`
+	UserID    string `json:"user_id,omitempty"`
+	ProductID string `json:"product_id,omitempty"`
 }
 
 // LicenseListResponse represents a paginated list of licenses
@@ -39,11 +68,129 @@ type LicenseListResponse struct {
 
 // LicenseStats represents license statistics
 type LicenseStats struct {
-	Total   int     `json:"total"`
-	Active  int     `json:"active"`
-	Expired int     `json:"expired"`
-	Revoked int     `json:"revoked"`
-	Revenue float64 `json:"revenue"`
+	Total     int     `json:"total"`
+	Active    int     `json:"active"`
+	Expired   int     `json:"expired"`
+	Revoked   int     `json:"revoked"`
+	Suspended int     `json:"suspended"`
+	Revenue   float64 `json:"revenue"`
+}
+
+// ValidateLicenseRequest represents a request to validate a license key
+type ValidateLicenseRequest struct {
+	LicenseKey string `json:"license_key"`
+	ProductID  string `json:"product_id,omitempty"`
+	DeviceID   string `json:"device_id,omitempty"`
+}
+
+// ValidationResult represents the detailed outcome of validating a license key
+type ValidationResult struct {
+	Valid bool `json:"valid"`
+	// Reason is one of the ValidationReasons codes
+	Reason    string     `json:"reason,omitempty"`
+	License   *License   `json:"license,omitempty"`
+	Features  []string   `json:"features,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// RemainingActivations is nil when the license has no seat limit
+	RemainingActivations *int `json:"remaining_activations,omitempty"`
+}
+
+// Err returns nil for a valid result, or an error matching the sentinel for its reason
+func (r *ValidationResult) Err() error {
+	if r.Valid {
+		return nil
+	}
+
+	sentinel := ErrValidationError
+	switch r.Reason {
+	case ValidationReasons.NotFound:
+		sentinel = ErrNotFoundError
+	case ValidationReasons.Expired:
+		sentinel = ErrLicenseExpired
+	case ValidationReasons.Revoked, ValidationReasons.Suspended:
+		sentinel = ErrLicenseInactive
+	case ValidationReasons.ProductMismatch:
+		sentinel = ErrProductMismatch
+	case ValidationReasons.SeatLimitReached:
+		sentinel = ErrSeatLimitReached
+	case ValidationReasons.HardwareMismatch:
+		sentinel = ErrHardwareMismatch
+	}
+	return &LicenseChainError{
+		Type:    sentinel.Type,
+		Message: fmt.Sprintf("%s: %s", sentinel.Message, r.Reason),
+	}
+}
+
+// ValidationReasons contains validation reason codes
+var ValidationReasons = struct {
+	Valid            string
+	Invalid          string
+	NotFound         string
+	Expired          string
+	Revoked          string
+	Suspended        string
+	ProductMismatch  string
+	SeatLimitReached string
+	HardwareMismatch string
+}{
+	Valid:            "valid",
+	Invalid:          "invalid",
+	NotFound:         "not_found",
+	Expired:          "expired",
+	Revoked:          "revoked",
+	Suspended:        "suspended",
+	ProductMismatch:  "product_mismatch",
+	SeatLimitReached: "seat_limit_reached",
+	HardwareMismatch: "hardware_mismatch",
+}
+
+// LicenseStatuses contains license status constants
+var LicenseStatuses = struct {
+	Active    string
+	Expired   string
+	Revoked   string
+	Suspended string
+}{
+	Active:    "active",
+	Expired:   "expired",
+	Revoked:   "revoked",
+	Suspended: "suspended",
+}
+
+// Activation represents a seat of a license occupied by a device
+type Activation struct {
+	ID           string                 `json:"id"`
+	LicenseID    string                 `json:"license_id"`
+	DeviceID     string                 `json:"device_id"`
+	MaxSeats     int                    `json:"max_seats"`
+	CurrentSeats int                    `json:"current_seats"`
+	CreatedAt    time.Time              `json:"created_at"`
+	LastSeenAt   *time.Time             `json:"last_seen_at,omitempty"`
+	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// ActivateLicenseRequest represents a request to activate or deactivate a license on a device
+type ActivateLicenseRequest struct {
+	LicenseKey string                 `json:"license_key"`
+	DeviceID   string                 `json:"device_id"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// ActivationListResponse represents the activations of a license
+type ActivationListResponse struct {
+	Data         []Activation `json:"data"`
+	MaxSeats     int          `json:"max_seats"`
+	CurrentSeats int          `json:"current_seats"`
+}
+
+// Lease represents a floating license seat checked out by a device
+type Lease struct {
+	ID                   string    `json:"id"`
+	LicenseID            string    `json:"license_id"`
+	DeviceID             string    `json:"device_id"`
+	ExpiresAt            time.Time `json:"expires_at"`
+	RenewIntervalSeconds int       `json:"renew_interval_seconds,omitempty"`
 }
 
 // User represents a user in the LicenseChain system
@@ -162,6 +309,77 @@ type WebhookListResponse struct {
 	Limit int       `json:"limit"`
 }
 
+// WebhookTestResponse represents the outcome of a test webhook delivery
+type WebhookTestResponse struct {
+	Delivered  bool   `json:"delivered"`
+	StatusCode int    `json:"status_code"`
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+// ListEventsRequest represents a request to list account events, oldest first
+type ListEventsRequest struct {
+	// After is the cursor returned as NextCursor by the previous page
+	After string `json:"after,omitempty"`
+	// Types restricts results to these event types
+	Types []string `json:"types,omitempty"`
+	Limit int      `json:"limit,omitempty"`
+	// Order is "desc" to list newest first; the default is oldest first
+	Order string `json:"order,omitempty"`
+}
+
+// EventListResponse represents a page of account events
+type EventListResponse struct {
+	Data       []WebhookEvent `json:"data"`
+	HasMore    bool           `json:"has_more"`
+	NextCursor string         `json:"next_cursor"`
+}
+
+// Payment represents a payment in the LicenseChain system
+type Payment struct {
+	ID        string                 `json:"id"`
+	UserID    string                 `json:"user_id"`
+	ProductID string                 `json:"product_id"`
+	LicenseID string                 `json:"license_id,omitempty"`
+	Amount    float64                `json:"amount"`
+	Currency  string                 `json:"currency"`
+	Status    string                 `json:"status"`
+	CreatedAt time.Time              `json:"created_at"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// WebhookEvent represents a webhook delivery envelope
+type WebhookEvent struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	Timestamp string          `json:"timestamp,omitempty"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// LicenseEvent represents a license.* webhook event
+type LicenseEvent struct {
+	WebhookEvent
+	License License
+}
+
+// UserEvent represents a user.* webhook event
+type UserEvent struct {
+	WebhookEvent
+	User User
+}
+
+// ProductEvent represents a product.* webhook event
+type ProductEvent struct {
+	WebhookEvent
+	Product Product
+}
+
+// PaymentEvent represents a payment.* webhook event
+type PaymentEvent struct {
+	WebhookEvent
+	Payment Payment
+}
+
 // HealthResponse represents a health check response
 type HealthResponse struct {
 	Status    string `json:"status"`
@@ -0,0 +1,295 @@
+package client
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Signing algorithms supported for license tokens
+const (
+	LicenseTokenAlgEdDSA = "EdDSA"
+	LicenseTokenAlgRS256 = "RS256"
+)
+
+// LicenseTokenHeader is the header segment of a signed license token
+type LicenseTokenHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ,omitempty"`
+	Kid string `json:"kid,omitempty"`
+}
+
+// LicenseTokenClaims is the payload segment of a signed license token
+type LicenseTokenClaims struct {
+	License License `json:"license"`
+	// LicenseKeyHash is the hex SHA-256 of the license key the token was issued for
+	LicenseKeyHash string    `json:"license_key_hash"`
+	IssuedAt       time.Time `json:"iat"`
+	// NotBefore is when the token becomes valid; zero means from IssuedAt
+	NotBefore time.Time `json:"nbf"`
+	// ExpiresAt is when the token stops being valid, independently of the
+	// license's own expiry, so a revoked license stops verifying offline
+	ExpiresAt time.Time `json:"exp"`
+}
+
+// OfflineVerifier verifies signed license tokens without contacting the API.
+//
+// A license token is a compact, JWT-like string of three base64url segments
+// (header, claims, signature) issued by the API for a license key. It is
+// signed with the LicenseChain private key; the verifier only needs the
+// matching public key, which should be pinned in the application binary.
+type OfflineVerifier struct {
+	publicKey crypto.PublicKey
+	productID string
+	leeway    time.Duration
+	now       func() time.Time
+}
+
+// NewOfflineVerifier creates a verifier for the pinned publicKey, which must
+// be an ed25519.PublicKey or *rsa.PublicKey. If productID is not empty,
+// tokens issued for other products are rejected.
+func NewOfflineVerifier(publicKey crypto.PublicKey, productID string) (*OfflineVerifier, error) {
+	switch key := publicKey.(type) {
+	case ed25519.PublicKey:
+		if len(key) != ed25519.PublicKeySize {
+			return nil, NewValidationError(fmt.Sprintf("invalid Ed25519 public key length %d", len(key)))
+		}
+	case *rsa.PublicKey:
+		if key == nil {
+			return nil, NewValidationError("RSA public key cannot be nil")
+		}
+	default:
+		return nil, NewValidationError(fmt.Sprintf("unsupported public key type %T", publicKey))
+	}
+	return &OfflineVerifier{
+		publicKey: publicKey,
+		productID: productID,
+		leeway:    5 * time.Minute,
+		now:       time.Now,
+	}, nil
+}
+
+// SetLeeway sets the clock skew tolerated when checking expiry, validity window and issue time
+func (v *OfflineVerifier) SetLeeway(leeway time.Duration) {
+	if leeway >= 0 {
+		v.leeway = leeway
+	}
+}
+
+// Verify checks the token's signature, validity window, license key,
+// expiry, status and bound product and returns the license it carries.
+// licenseKey is the key the token must have been issued for. Tokens without
+// an expiry are rejected, so fetch a fresh token while online before the
+// saved one expires.
+func (v *OfflineVerifier) Verify(token, licenseKey string) (*License, error) {
+	if err := ValidateNotEmpty(licenseKey, "license_key"); err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 {
+		return nil, newInvalidTokenError("malformed token", nil)
+	}
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, newInvalidTokenError("malformed header", err)
+	}
+	var header LicenseTokenHeader
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return nil, newInvalidTokenError("malformed header", err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, newInvalidTokenError("malformed signature", err)
+	}
+	if err := v.verifySignature(header.Alg, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, err
+	}
+
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, newInvalidTokenError("malformed claims", err)
+	}
+	var claims LicenseTokenClaims
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		return nil, newInvalidTokenError("malformed claims", err)
+	}
+
+	now := v.now()
+	if claims.IssuedAt.After(now.Add(v.leeway)) {
+		return nil, newInvalidTokenError("token issued in the future", nil)
+	}
+	if !claims.NotBefore.IsZero() && claims.NotBefore.After(now.Add(v.leeway)) {
+		return nil, newInvalidTokenError("token not yet valid", nil)
+	}
+	if claims.ExpiresAt.IsZero() {
+		return nil, newInvalidTokenError("token has no expiry", nil)
+	}
+	if now.Add(-v.leeway).After(claims.ExpiresAt) {
+		return nil, newInvalidTokenError(fmt.Sprintf("token expired at %s", claims.ExpiresAt.Format(time.RFC3339)), nil)
+	}
+	if !hmac.Equal([]byte(strings.ToLower(claims.LicenseKeyHash)), []byte(SHA256(licenseKey))) {
+		return nil, newInvalidTokenError("token was issued for a different license key", nil)
+	}
+	license := claims.License
+	if license.ExpiresAt != nil && now.Add(-v.leeway).After(*license.ExpiresAt) {
+		return &license, &LicenseChainError{
+			Type:    ErrLicenseExpired.Type,
+			Message: fmt.Sprintf("License expired at %s", license.ExpiresAt.Format(time.RFC3339)),
+		}
+	}
+	if license.Status != LicenseStatuses.Active {
+		return &license, &LicenseChainError{
+			Type:    ErrLicenseInactive.Type,
+			Message: fmt.Sprintf("License is %s", license.Status),
+		}
+	}
+	if v.productID != "" && license.ProductID != v.productID {
+		return &license, &LicenseChainError{
+			Type:    ErrProductMismatch.Type,
+			Message: fmt.Sprintf("License is not valid for product %s", v.productID),
+		}
+	}
+
+	return &license, nil
+}
+
+// VerifyFile loads a token saved with SaveLicenseToken and verifies it for licenseKey
+func (v *OfflineVerifier) VerifyFile(path, licenseKey string) (*License, error) {
+	token, err := LoadLicenseToken(path)
+	if err != nil {
+		return nil, err
+	}
+	return v.Verify(token, licenseKey)
+}
+
+func (v *OfflineVerifier) verifySignature(alg string, signed, signature []byte) error {
+	switch key := v.publicKey.(type) {
+	case ed25519.PublicKey:
+		if alg != LicenseTokenAlgEdDSA {
+			return newInvalidTokenError(fmt.Sprintf("unexpected algorithm %q", alg), nil)
+		}
+		if !ed25519.Verify(key, signed, signature) {
+			return newInvalidTokenError("invalid signature", nil)
+		}
+	case *rsa.PublicKey:
+		if alg != LicenseTokenAlgRS256 {
+			return newInvalidTokenError(fmt.Sprintf("unexpected algorithm %q", alg), nil)
+		}
+		digest := sha256.Sum256(signed)
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return newInvalidTokenError("invalid signature", err)
+		}
+	}
+	return nil
+}
+
+func newInvalidTokenError(reason string, err error) *LicenseChainError {
+	return &LicenseChainError{
+		Type:    ErrInvalidToken.Type,
+		Message: fmt.Sprintf("Invalid license token: %s", reason),
+		Err:     err,
+	}
+}
+
+// ParsePublicKeyPEM parses a PEM-encoded PKIX public key for use with NewOfflineVerifier
+func ParsePublicKeyPEM(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, NewValidationError("no PEM block found in public key")
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %v", err)
+	}
+	return publicKey, nil
+}
+
+// FetchLicenseToken retrieves a signed license token for offline verification
+func (c *LicenseChainClient) FetchLicenseToken(licenseKey string) (string, error) {
+	return c.FetchLicenseTokenCtx(context.Background(), licenseKey)
+}
+
+// FetchLicenseTokenCtx retrieves a signed license token for offline verification using the provided context
+func (c *LicenseChainClient) FetchLicenseTokenCtx(ctx context.Context, licenseKey string) (string, error) {
+	if err := ValidateNotEmpty(licenseKey, "license_key"); err != nil {
+		return "", err
+	}
+
+	req := map[string]string{"license_key": licenseKey}
+	var response struct {
+		Data struct {
+			Token string `json:"token"`
+		} `json:"data"`
+	}
+
+	err := c.makeRequest(withoutIdempotencyKey(ctx), "POST", "/licenses/token", req, &response)
+	if err != nil {
+		return "", err
+	}
+	if response.Data.Token == "" {
+		return "", ErrInvalidResponse
+	}
+
+	return response.Data.Token, nil
+}
+
+// SaveLicenseToken atomically writes a license token to path, readable only by the current user
+func SaveLicenseToken(path, token string) error {
+	return writeFileAtomic(path, []byte(token), 0600)
+}
+
+// LoadLicenseToken reads a license token written by SaveLicenseToken
+func LoadLicenseToken(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// writeFileAtomic writes data to a temporary file in the same directory and
+// renames it over path, so readers never observe a partial write
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpName, perm); err != nil {
+		return err
+	}
+	return os.Rename(tmpName, path)
+}
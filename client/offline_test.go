@@ -0,0 +1,130 @@
+package client
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signLicenseToken builds a token signed with key, which must be an
+// ed25519.PrivateKey or *rsa.PrivateKey
+func signLicenseToken(t *testing.T, key crypto.Signer, claims LicenseTokenClaims) string {
+	t.Helper()
+
+	alg := LicenseTokenAlgEdDSA
+	if _, ok := key.(*rsa.PrivateKey); ok {
+		alg = LicenseTokenAlgRS256
+	}
+	header, err := json.Marshal(LicenseTokenHeader{Alg: alg, Typ: "JWT"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	var signature []byte
+	switch key := key.(type) {
+	case ed25519.PrivateKey:
+		signature = ed25519.Sign(key, []byte(signed))
+	case *rsa.PrivateKey:
+		digest := sha256.Sum256([]byte(signed))
+		signature, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func validTokenClaims(now time.Time) LicenseTokenClaims {
+	return LicenseTokenClaims{
+		License:        License{ID: "lic_1", ProductID: "prod_1", Status: LicenseStatuses.Active},
+		LicenseKeyHash: SHA256("LICENSE"),
+		IssuedAt:       now.Add(-time.Minute),
+		ExpiresAt:      now.Add(time.Hour),
+	}
+}
+
+func TestOfflineVerifierVerify(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	_, otherKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	now := time.Now()
+	past := now.Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		key     crypto.Signer
+		modify  func(c *LicenseTokenClaims)
+		license string
+		wantErr error
+	}{
+		{"valid", privateKey, nil, "LICENSE", nil},
+		{"wrong signer", otherKey, nil, "LICENSE", ErrInvalidToken},
+		{"other license key", privateKey, nil, "OTHER", ErrInvalidToken},
+		{"token expired", privateKey, func(c *LicenseTokenClaims) { c.ExpiresAt = past }, "LICENSE", ErrInvalidToken},
+		{"token without expiry", privateKey, func(c *LicenseTokenClaims) { c.ExpiresAt = time.Time{} }, "LICENSE", ErrInvalidToken},
+		{"token not yet valid", privateKey, func(c *LicenseTokenClaims) { c.NotBefore = now.Add(time.Hour) }, "LICENSE", ErrInvalidToken},
+		{"issued in the future", privateKey, func(c *LicenseTokenClaims) { c.IssuedAt = now.Add(time.Hour) }, "LICENSE", ErrInvalidToken},
+		{"within leeway", privateKey, func(c *LicenseTokenClaims) { c.ExpiresAt = now.Add(-time.Minute) }, "LICENSE", nil},
+		{"license expired", privateKey, func(c *LicenseTokenClaims) { c.License.ExpiresAt = &past }, "LICENSE", ErrLicenseExpired},
+		{"license revoked", privateKey, func(c *LicenseTokenClaims) { c.License.Status = LicenseStatuses.Revoked }, "LICENSE", ErrLicenseInactive},
+		{"other product", privateKey, func(c *LicenseTokenClaims) { c.License.ProductID = "prod_2" }, "LICENSE", ErrProductMismatch},
+	}
+
+	verifier, err := NewOfflineVerifier(publicKey, "prod_1")
+	require.NoError(t, err)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := validTokenClaims(now)
+			if tt.modify != nil {
+				tt.modify(&claims)
+			}
+			license, err := verifier.Verify(signLicenseToken(t, tt.key, claims), tt.license)
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				assert.Equal(t, "lic_1", license.ID)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOfflineVerifierRS256AndFile(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	verifier, err := NewOfflineVerifier(&privateKey.PublicKey, "")
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "license.token")
+	require.NoError(t, SaveLicenseToken(path, signLicenseToken(t, privateKey, validTokenClaims(time.Now()))))
+
+	license, err := verifier.VerifyFile(path, "LICENSE")
+	require.NoError(t, err)
+	assert.Equal(t, "lic_1", license.ID)
+}
+
+func TestOfflineVerifierRejectsBadInput(t *testing.T) {
+	_, err := NewOfflineVerifier(ed25519.PublicKey("short"), "")
+	assert.ErrorIs(t, err, ErrValidationError)
+	_, err = NewOfflineVerifier("not a key", "")
+	assert.ErrorIs(t, err, ErrValidationError)
+
+	publicKey, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	verifier, err := NewOfflineVerifier(publicKey, "")
+	require.NoError(t, err)
+	for _, token := range []string{"", "a.b", "a.b.c", "!!.!!.!!"} {
+		_, err := verifier.Verify(token, "LICENSE")
+		assert.ErrorIs(t, err, ErrInvalidToken, token)
+	}
+}
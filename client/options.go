@@ -0,0 +1,239 @@
+package client
+
+import (
+	"crypto/ed25519"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultBaseURL is the LicenseChain API base URL used when none is configured
+	DefaultBaseURL = "https://api.licensechain.app"
+	// DefaultTimeout is the request timeout used when none is configured
+	DefaultTimeout = 30 * time.Second
+	// DefaultUserAgent is the User-Agent sent when none is configured
+	DefaultUserAgent = "LicenseChain-Go-SDK/1.0.0"
+	// DefaultAPIVersion is the X-API-Version sent when none is configured
+	DefaultAPIVersion = "1.0"
+)
+
+// Option configures a LicenseChainClient
+type Option func(*clientConfig)
+
+// clientConfig collects option values before the client is built
+type clientConfig struct {
+	baseURL     string
+	userAgent   string
+	apiVersion  string
+	timeout     time.Duration
+	httpClient  *http.Client
+	transport   http.RoundTripper
+	headers     http.Header
+	retryPolicy RetryPolicy
+
+	validationCache *ValidationCacheConfig
+}
+
+// WithBaseURL sets the API base URL
+func WithBaseURL(baseURL string) Option {
+	return func(cfg *clientConfig) {
+		if baseURL != "" {
+			cfg.baseURL = strings.TrimRight(baseURL, "/")
+		}
+	}
+}
+
+// WithHTTPClient sets the http.Client used for requests. The client is
+// copied, so later options such as WithTransport do not modify it.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(cfg *clientConfig) {
+		cfg.httpClient = httpClient
+	}
+}
+
+// WithTransport sets the http.RoundTripper used for requests, e.g. to
+// configure a proxy or custom TLS settings
+func WithTransport(transport http.RoundTripper) Option {
+	return func(cfg *clientConfig) {
+		cfg.transport = transport
+	}
+}
+
+// WithTimeout sets the per-request timeout
+func WithTimeout(timeout time.Duration) Option {
+	return func(cfg *clientConfig) {
+		if timeout > 0 {
+			cfg.timeout = timeout
+		}
+	}
+}
+
+// WithUserAgent sets the User-Agent header
+func WithUserAgent(userAgent string) Option {
+	return func(cfg *clientConfig) {
+		if userAgent != "" {
+			cfg.userAgent = userAgent
+		}
+	}
+}
+
+// WithAPIVersion sets the X-API-Version header
+func WithAPIVersion(version string) Option {
+	return func(cfg *clientConfig) {
+		if version != "" {
+			cfg.apiVersion = version
+		}
+	}
+}
+
+// WithHeaders adds headers sent with every request. Repeated calls are merged.
+func WithHeaders(headers map[string]string) Option {
+	return func(cfg *clientConfig) {
+		for key, value := range headers {
+			cfg.headers.Set(key, value)
+		}
+	}
+}
+
+// WithRetryPolicy sets the retry policy
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(cfg *clientConfig) {
+		cfg.retryPolicy = policy.withDefaults()
+	}
+}
+
+// WithRetries sets the maximum number of attempts per request
+func WithRetries(retries int) Option {
+	return func(cfg *clientConfig) {
+		if retries > 0 {
+			cfg.retryPolicy.MaxAttempts = retries
+		}
+	}
+}
+
+// WithValidationCache caches license validation results in memory so
+// repeated validations of the same key do not each hit the API
+func WithValidationCache(config ValidationCacheConfig) Option {
+	return func(cfg *clientConfig) {
+		cfg.validationCache = &config
+	}
+}
+
+// newClientConfig returns the default configuration
+func newClientConfig() *clientConfig {
+	return &clientConfig{
+		baseURL:     DefaultBaseURL,
+		userAgent:   DefaultUserAgent,
+		apiVersion:  DefaultAPIVersion,
+		headers:     http.Header{},
+		retryPolicy: DefaultRetryPolicy(),
+	}
+}
+
+// buildHTTPClient returns the http.Client described by the configuration
+func (cfg *clientConfig) buildHTTPClient() *http.Client {
+	httpClient := &http.Client{Timeout: DefaultTimeout}
+	if cfg.httpClient != nil {
+		copied := *cfg.httpClient
+		httpClient = &copied
+	}
+	if cfg.timeout > 0 {
+		httpClient.Timeout = cfg.timeout
+	}
+	if cfg.transport != nil {
+		httpClient.Transport = cfg.transport
+	}
+	return httpClient
+}
+
+// ValidateOption constrains license validation
+type ValidateOption func(*ValidateLicenseRequest)
+
+// WithProductID makes validation fail unless the license belongs to productID
+func WithProductID(productID string) ValidateOption {
+	return func(req *ValidateLicenseRequest) {
+		req.ProductID = productID
+	}
+}
+
+// WithDeviceID makes validation fail unless the license is usable on deviceID,
+// e.g. a hardware ID from GetHardwareID
+func WithDeviceID(deviceID string) ValidateOption {
+	return func(req *ValidateLicenseRequest) {
+		req.DeviceID = deviceID
+	}
+}
+
+// WebhookOption configures a WebhookHandler
+type WebhookOption func(*WebhookHandler)
+
+// WithSignatureHeader sets the request header carrying the webhook signature
+func WithSignatureHeader(name string) WebhookOption {
+	return func(wh *WebhookHandler) {
+		if name != "" {
+			wh.signatureHeader = name
+		}
+	}
+}
+
+// WithTimestampHeader sets the request header carrying the webhook timestamp
+func WithTimestampHeader(name string) WebhookOption {
+	return func(wh *WebhookHandler) {
+		if name != "" {
+			wh.timestampHeader = name
+		}
+	}
+}
+
+// WithMaxBodyBytes limits the size of webhook request bodies
+func WithMaxBodyBytes(n int64) WebhookOption {
+	return func(wh *WebhookHandler) {
+		if n > 0 {
+			wh.maxBodyBytes = n
+		}
+	}
+}
+
+// WithSecrets adds secrets accepted alongside the primary one, e.g. during
+// rotation. Empty secrets are ignored.
+func WithSecrets(secrets ...string) WebhookOption {
+	return func(wh *WebhookHandler) {
+		wh.secrets = nonEmptySecrets(wh.secrets, secrets...)
+	}
+}
+
+// WithEd25519PublicKeys accepts v1_ed25519 signatures made with the matching
+// private keys. Keys that are not ed25519.PublicKeySize bytes long are ignored.
+func WithEd25519PublicKeys(keys ...ed25519.PublicKey) WebhookOption {
+	return func(wh *WebhookHandler) {
+		for _, key := range keys {
+			if len(key) == ed25519.PublicKeySize {
+				wh.publicKeys = append(wh.publicKeys, key)
+			}
+		}
+	}
+}
+
+// WithLegacySignatures controls whether unversioned hex HMAC signatures are accepted
+func WithLegacySignatures(allowed bool) WebhookOption {
+	return func(wh *WebhookHandler) {
+		wh.allowLegacy = allowed
+	}
+}
+
+// WithAsyncProcessing acknowledges deliveries once queued and runs handlers
+// on a bounded worker pool with retries. Call Close or Shutdown to drain it.
+func WithAsyncProcessing(config AsyncConfig) WebhookOption {
+	return func(wh *WebhookHandler) {
+		wh.asyncConfig = &config
+	}
+}
+
+// WithSeenStore sets the store used to deduplicate webhook deliveries by
+// event ID; nil disables deduplication
+func WithSeenStore(store WebhookSeenStore) WebhookOption {
+	return func(wh *WebhookHandler) {
+		wh.seen = store
+	}
+}
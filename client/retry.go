@@ -0,0 +1,218 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried.
+//
+// Only transient failures are retried: rate limiting (429), server errors
+// (5xx), request timeouts (408) and network errors. Validation,
+// authentication and not-found errors are returned immediately.
+// Non-idempotent requests (POST, PATCH) are only retried on 429 unless
+// RetryNonIdempotent is set. Requests sent by LicenseChainClient carry an
+// Idempotency-Key, so the client retries them regardless.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first
+	MaxAttempts int
+	// InitialDelay is the backoff before the first retry; it doubles on each retry
+	InitialDelay time.Duration
+	// MaxDelay caps a single backoff. A Retry-After longer than MaxDelay
+	// stops retrying and the rate-limit error is returned.
+	MaxDelay time.Duration
+	// MaxElapsedTime caps the total time spent retrying a request
+	MaxElapsedTime time.Duration
+	// Jitter randomizes each backoff by up to this fraction (0 to 1)
+	Jitter float64
+	// RetryNonIdempotent allows retrying POST and PATCH requests on transient failures
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns the retry policy used when none is configured
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialDelay:   time.Second,
+		MaxDelay:       30 * time.Second,
+		MaxElapsedTime: 2 * time.Minute,
+		Jitter:         0.2,
+	}
+}
+
+// NoRetryPolicy returns a policy that makes a single attempt
+func NoRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 1}
+}
+
+// withDefaults fills unset fields from DefaultRetryPolicy
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	def := DefaultRetryPolicy()
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = def.MaxAttempts
+	}
+	if p.InitialDelay <= 0 {
+		p.InitialDelay = def.InitialDelay
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = def.MaxDelay
+	}
+	if p.MaxElapsedTime <= 0 {
+		p.MaxElapsedTime = def.MaxElapsedTime
+	}
+	if p.Jitter < 0 {
+		p.Jitter = 0
+	}
+	if p.Jitter > 1 {
+		p.Jitter = 1
+	}
+	return p
+}
+
+// Do calls fn until it succeeds, returns a non-retryable error, or the
+// attempt or elapsed-time budget is exhausted. A Retry-After hint beyond
+// MaxDelay or the elapsed-time budget returns the error without waiting.
+// Backoff sleeps end early when ctx is done.
+func (p RetryPolicy) Do(ctx context.Context, method string, fn func() error) error {
+	p = p.withDefaults()
+	start := time.Now()
+
+	var err error
+	for attempt := 0; attempt < p.MaxAttempts; attempt++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err = fn(); err == nil {
+			return nil
+		}
+		if attempt == p.MaxAttempts-1 || !p.ShouldRetry(method, err) {
+			return err
+		}
+
+		delay := p.Backoff(attempt, err)
+		if retryAfter(err) > p.MaxDelay || time.Since(start)+delay > p.MaxElapsedTime {
+			return err
+		}
+		if sleepErr := SleepCtx(ctx, delay); sleepErr != nil {
+			return sleepErr
+		}
+	}
+	return err
+}
+
+// ShouldRetry reports whether a request with the given method that failed
+// with err may be retried
+func (p RetryPolicy) ShouldRetry(method string, err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var lcErr *LicenseChainError
+	if errors.As(err, &lcErr) {
+		switch lcErr.Type {
+		case ErrRateLimitError.Type:
+			// The server rejected the request without processing it
+			return true
+		case ErrServerError.Type, ErrNetworkError.Type:
+		case ErrHTTPError.Type:
+			if lcErr.Code == http.StatusTooManyRequests {
+				return true
+			}
+			if lcErr.Code != http.StatusRequestTimeout && lcErr.Code < 500 {
+				return false
+			}
+		default:
+			return false
+		}
+		return p.RetryNonIdempotent || isIdempotent(method)
+	}
+
+	// Errors from http.Client.Do are transport failures
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return p.RetryNonIdempotent || isIdempotent(method)
+	}
+	return false
+}
+
+// Backoff returns the delay before the retry following the given zero-based
+// attempt. A Retry-After hint carried by err takes precedence over the
+// exponential schedule and is returned as is, even beyond MaxDelay.
+func (p RetryPolicy) Backoff(attempt int, err error) time.Duration {
+	p = p.withDefaults()
+
+	if hint := retryAfter(err); hint > 0 {
+		return hint
+	}
+
+	delay := float64(p.InitialDelay) * math.Pow(2, float64(attempt))
+	if delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+	if p.Jitter > 0 {
+		delay += delay * p.Jitter * (2*jitterFloat64() - 1)
+	}
+	return time.Duration(delay)
+}
+
+// retryAfter returns the Retry-After delay carried by err, or zero
+func retryAfter(err error) time.Duration {
+	var lcErr *LicenseChainError
+	if errors.As(err, &lcErr) {
+		return lcErr.RetryAfter
+	}
+	return 0
+}
+
+// isTransientError reports whether err is a temporary failure that may
+// succeed on a later attempt, regardless of the request method
+func isTransientError(err error) bool {
+	return RetryPolicy{RetryNonIdempotent: true}.ShouldRetry(http.MethodPost, err)
+}
+
+// isIdempotent reports whether an HTTP method is safe to repeat
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+var jitterSource = struct {
+	sync.Mutex
+	rng *rand.Rand
+}{rng: rand.New(rand.NewSource(time.Now().UnixNano()))}
+
+// jitterFloat64 returns a pseudo-random number in [0, 1)
+func jitterFloat64() float64 {
+	jitterSource.Lock()
+	defer jitterSource.Unlock()
+	return jitterSource.rng.Float64()
+}
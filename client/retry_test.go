@@ -0,0 +1,137 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldRetry(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	transportErr := &url.Error{Op: "Get", URL: "http://example.com", Err: errors.New("connection reset")}
+
+	tests := []struct {
+		name   string
+		method string
+		err    error
+		want   bool
+	}{
+		{"rate limit on POST", http.MethodPost, NewRateLimitError("slow down"), true},
+		{"server error on GET", http.MethodGet, NewServerError("boom"), true},
+		{"server error on POST", http.MethodPost, NewServerError("boom"), false},
+		{"network error on GET", http.MethodGet, NewNetworkError(errors.New("reset")), true},
+		{"transport error on DELETE", http.MethodDelete, transportErr, true},
+		{"transport error on PATCH", http.MethodPatch, transportErr, false},
+		{"429 http error on POST", http.MethodPost, NewHTTPError(http.StatusTooManyRequests, "slow down"), true},
+		{"408 http error on GET", http.MethodGet, NewHTTPError(http.StatusRequestTimeout, "timeout"), true},
+		{"409 http error on GET", http.MethodGet, NewHTTPError(http.StatusConflict, "conflict"), false},
+		{"validation error", http.MethodGet, NewValidationError("bad"), false},
+		{"authentication error", http.MethodGet, NewAuthenticationError("denied"), false},
+		{"not found error", http.MethodGet, NewNotFoundError("missing"), false},
+		{"context canceled", http.MethodGet, context.Canceled, false},
+		{"deadline exceeded", http.MethodGet, NewNetworkError(context.DeadlineExceeded), false},
+		{"plain error", http.MethodGet, errors.New("unknown"), false},
+		{"nil error", http.MethodGet, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, policy.ShouldRetry(tt.method, tt.err))
+		})
+	}
+
+	policy.RetryNonIdempotent = true
+	assert.True(t, policy.ShouldRetry(http.MethodPost, NewServerError("boom")))
+}
+
+func TestBackoff(t *testing.T) {
+	policy := RetryPolicy{InitialDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	assert.Equal(t, 100*time.Millisecond, policy.Backoff(0, nil))
+	assert.Equal(t, 400*time.Millisecond, policy.Backoff(2, nil))
+	assert.Equal(t, time.Second, policy.Backoff(10, nil), "backoff is capped at MaxDelay")
+
+	rateLimited := NewRateLimitError("slow down")
+	rateLimited.RetryAfter = 5 * time.Second
+	assert.Equal(t, 5*time.Second, policy.Backoff(0, rateLimited), "Retry-After takes precedence")
+
+	policy.Jitter = 0.5
+	for i := 0; i < 20; i++ {
+		delay := policy.Backoff(0, nil)
+		assert.GreaterOrEqual(t, delay, 50*time.Millisecond)
+		assert.LessOrEqual(t, delay, 150*time.Millisecond)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	assert.Equal(t, time.Duration(0), parseRetryAfter(""))
+	assert.Equal(t, 3*time.Second, parseRetryAfter("3"))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("-1"))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon"))
+	assert.Equal(t, time.Duration(0), parseRetryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)))
+
+	delay := parseRetryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	assert.Greater(t, delay, 50*time.Second)
+	assert.LessOrEqual(t, delay, time.Minute)
+}
+
+func TestRetryPolicyDo(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+
+	attempts := 0
+	err := policy.Do(context.Background(), http.MethodGet, func() error {
+		attempts++
+		if attempts < 3 {
+			return NewServerError("boom")
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+
+	attempts = 0
+	err = policy.Do(context.Background(), http.MethodGet, func() error {
+		attempts++
+		return NewValidationError("bad")
+	})
+	assert.ErrorIs(t, err, ErrValidationError)
+	assert.Equal(t, 1, attempts, "permanent errors are not retried")
+
+	attempts = 0
+	err = policy.Do(context.Background(), http.MethodGet, func() error {
+		attempts++
+		rateLimited := NewRateLimitError("slow down")
+		rateLimited.RetryAfter = time.Hour
+		return rateLimited
+	})
+	assert.ErrorIs(t, err, ErrRateLimitError)
+	assert.Equal(t, 1, attempts, "a Retry-After beyond MaxDelay is not waited for")
+}
+
+func TestClientRetriesWithRetryAfter(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"data":{"id":"123e4567-e89b-12d3-a456-426614174000"}}`))
+	}))
+	defer srv.Close()
+
+	lc := NewClient("key", WithBaseURL(srv.URL), WithRetryPolicy(RetryPolicy{MaxAttempts: 2, MaxDelay: 2 * time.Second}))
+	start := time.Now()
+	license, err := lc.GetLicense("123e4567-e89b-12d3-a456-426614174000")
+	require.NoError(t, err)
+	assert.Equal(t, "123e4567-e89b-12d3-a456-426614174000", license.ID)
+	assert.Equal(t, int32(2), requests.Load())
+	assert.GreaterOrEqual(t, time.Since(start), time.Second, "the client waits for Retry-After")
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/md5"
 	"crypto/sha1"
@@ -37,6 +38,27 @@ func ValidateUUID(uuid string) bool {
 	return uuidRegex.MatchString(strings.ToLower(uuid))
 }
 
+// ValidateLicenseStatus validates a license status against LicenseStatuses
+func ValidateLicenseStatus(status string) bool {
+	switch status {
+	case LicenseStatuses.Active, LicenseStatuses.Expired, LicenseStatuses.Revoked, LicenseStatuses.Suspended:
+		return true
+	}
+	return false
+}
+
+// ValidateWebhookEvent validates a webhook event name against WebhookEvents
+func ValidateWebhookEvent(event string) bool {
+	switch event {
+	case WebhookEvents.LicenseCreated, WebhookEvents.LicenseUpdated, WebhookEvents.LicenseRevoked, WebhookEvents.LicenseExpired,
+		WebhookEvents.UserCreated, WebhookEvents.UserUpdated, WebhookEvents.UserDeleted,
+		WebhookEvents.ProductCreated, WebhookEvents.ProductUpdated, WebhookEvents.ProductDeleted,
+		WebhookEvents.PaymentCompleted, WebhookEvents.PaymentFailed, WebhookEvents.PaymentRefunded:
+		return true
+	}
+	return false
+}
+
 // ValidateAmount validates an amount
 func ValidateAmount(amount float64) bool {
 	return amount > 0 && !math.IsNaN(amount) && !math.IsInf(amount, 0)
@@ -169,13 +191,24 @@ func VerifyWebhookSignature(payload, signature, secret string) bool {
 
 // RetryWithBackoff retries a function with exponential backoff
 func RetryWithBackoff(fn func() error, maxRetries int, initialDelay time.Duration) error {
+	return RetryWithBackoffCtx(context.Background(), fn, maxRetries, initialDelay)
+}
+
+// RetryWithBackoffCtx retries a function with exponential backoff, aborting
+// remaining attempts and backoff sleeps once ctx is done
+func RetryWithBackoffCtx(ctx context.Context, fn func() error, maxRetries int, initialDelay time.Duration) error {
 	var lastErr error
 	for i := 0; i < maxRetries; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		if err := fn(); err != nil {
 			lastErr = err
 			if i < maxRetries-1 {
 				delay := time.Duration(float64(initialDelay) * math.Pow(2, float64(i)))
-				time.Sleep(delay)
+				if err := SleepCtx(ctx, delay); err != nil {
+					return err
+				}
 			}
 		} else {
 			return nil
@@ -333,6 +366,18 @@ func Sleep(duration time.Duration) {
 	time.Sleep(duration)
 }
 
+// SleepCtx pauses execution for the specified duration or until ctx is done
+func SleepCtx(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // SHA256 computes SHA256 hash
 func SHA256(data string) string {
 	h := sha256.Sum256([]byte(data))
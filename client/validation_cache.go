@@ -0,0 +1,485 @@
+package client
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ValidationCacheConfig configures the validation result cache enabled with WithValidationCache
+type ValidationCacheConfig struct {
+	// TTL is how long a valid result is served without contacting the API; defaults to 5 minutes
+	TTL time.Duration
+	// NegativeTTL is how long an invalid result is served; defaults to 1 minute
+	NegativeTTL time.Duration
+	// StaleWhileRevalidate is how long after TTL an entry is still served
+	// while a background refresh runs
+	StaleWhileRevalidate time.Duration
+	// OfflineGracePeriod is how long after TTL and StaleWhileRevalidate a
+	// valid result is still served when the API cannot be reached
+	OfflineGracePeriod time.Duration
+	// MaxEntries bounds the number of results held in memory; the least
+	// recently used are evicted first. Defaults to 10000.
+	MaxEntries int
+	// RefreshTimeout bounds each validation request, which may be shared by
+	// concurrent callers and background refreshes; defaults to 30 seconds
+	RefreshTimeout time.Duration
+	// Store persists results across process restarts, e.g. a FileCache, so
+	// OfflineGracePeriod still applies after a reboot. It is written after
+	// each successful validation and read only when the API cannot be
+	// reached. Store errors are ignored and tampered entries are discarded.
+	Store Cache
+}
+
+// withDefaults fills unset fields with default values
+func (cfg ValidationCacheConfig) withDefaults() ValidationCacheConfig {
+	if cfg.TTL <= 0 {
+		cfg.TTL = 5 * time.Minute
+	}
+	if cfg.NegativeTTL <= 0 {
+		cfg.NegativeTTL = time.Minute
+	}
+	if cfg.RefreshTimeout <= 0 {
+		cfg.RefreshTimeout = 30 * time.Second
+	}
+	if cfg.MaxEntries <= 0 {
+		cfg.MaxEntries = 10000
+	}
+	return cfg
+}
+
+// validationCache caches ValidationResults keyed by license key and
+// validation constraints, evicting expired and then least recently used
+// entries so that unique keys sent by clients cannot grow it without bound. It is safe for concurrent use.
+type validationCache struct {
+	config ValidationCacheConfig
+	now    func() time.Time
+
+	// storeMu serializes Store I/O, which is never done while holding mu
+	storeMu sync.Mutex
+
+	mu       sync.Mutex
+	order    *list.List
+	entries  map[string]*list.Element
+	sweptAt  time.Time
+	inflight map[string]*validationCacheCall
+}
+
+type validationCacheEntry struct {
+	key        string
+	result     ValidationResult
+	fetchedAt  time.Time
+	refreshing bool
+}
+
+// persistedValidation is the stored form of a validationCacheEntry
+type persistedValidation struct {
+	Result    ValidationResult `json:"result"`
+	FetchedAt time.Time        `json:"fetched_at"`
+}
+
+// validationCacheCall lets concurrent misses for the same key share one request
+type validationCacheCall struct {
+	done   chan struct{}
+	result *ValidationResult
+	err    error
+}
+
+type validationFetchFunc func(ctx context.Context) (*ValidationResult, error)
+
+func newValidationCache(config ValidationCacheConfig) *validationCache {
+	return &validationCache{
+		config:   config.withDefaults(),
+		now:      time.Now,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+		inflight: make(map[string]*validationCacheCall),
+	}
+}
+
+// validationCacheKey identifies a validation by license key and constraints
+func validationCacheKey(req ValidateLicenseRequest) string {
+	return req.LicenseKey + "\x00" + req.ProductID + "\x00" + req.DeviceID
+}
+
+// get returns a cached result when one is fresh or within the
+// stale-while-revalidate window, and otherwise fetches a new one
+func (vc *validationCache) get(ctx context.Context, req ValidateLicenseRequest, fetch validationFetchFunc) (*ValidationResult, error) {
+	key := validationCacheKey(req)
+
+	vc.mu.Lock()
+	entry, ok := vc.lookupLocked(key)
+	if ok {
+		age := vc.now().Sub(entry.fetchedAt)
+		ttl := vc.ttl(&entry.result)
+		if age < ttl {
+			result := entry.result.clone()
+			vc.mu.Unlock()
+			return &result, nil
+		}
+		if age < ttl+vc.config.StaleWhileRevalidate {
+			result := entry.result.clone()
+			if !entry.refreshing {
+				entry.refreshing = true
+				go vc.refresh(key, fetch)
+			}
+			vc.mu.Unlock()
+			return &result, nil
+		}
+	}
+	vc.mu.Unlock()
+
+	result, err := vc.fetch(ctx, key, fetch)
+	if err == nil {
+		return result, nil
+	}
+
+	// Fall back to a previously valid result while the API is unreachable
+	if isTransientError(err) {
+		if fallback, ok := vc.offlineFallback(key); ok {
+			return fallback, nil
+		}
+	}
+	return nil, err
+}
+
+// fetch performs a request for key, sharing it with concurrent callers.
+// The shared request runs detached from any caller's context, bounded by
+// RefreshTimeout, so one caller giving up does not fail the others; each
+// caller stops waiting only when its own ctx is done.
+func (vc *validationCache) fetch(ctx context.Context, key string, fetch validationFetchFunc) (*ValidationResult, error) {
+	vc.mu.Lock()
+	call, ok := vc.inflight[key]
+	if !ok {
+		call = &validationCacheCall{done: make(chan struct{})}
+		vc.inflight[key] = call
+		go vc.run(key, call, fetch)
+	}
+	vc.mu.Unlock()
+
+	select {
+	case <-call.done:
+		if call.err != nil {
+			return nil, call.err
+		}
+		result := call.result.clone()
+		return &result, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// run performs the shared request for call and stores a successful result
+func (vc *validationCache) run(key string, call *validationCacheCall, fetch validationFetchFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), vc.config.RefreshTimeout)
+	defer cancel()
+
+	call.result, call.err = fetch(ctx)
+	if call.err != nil && ctx.Err() != nil && errors.Is(call.err, ctx.Err()) {
+		// The API did not answer within RefreshTimeout. Report it as a
+		// network failure, not a context error, so that callers whose own
+		// ctx is still live fall back to the offline grace period.
+		call.err = &LicenseChainError{
+			Type:    ErrNetworkError.Type,
+			Message: fmt.Sprintf("Network error: validation request timed out after %s", vc.config.RefreshTimeout),
+		}
+	}
+
+	vc.mu.Lock()
+	delete(vc.inflight, key)
+	if call.err == nil {
+		vc.storeLocked(&validationCacheEntry{key: key, result: *call.result, fetchedAt: vc.now()})
+	} else if entry, ok := vc.lookupLocked(key); ok {
+		entry.refreshing = false
+	}
+	vc.mu.Unlock()
+	close(call.done)
+
+	if call.err == nil && vc.config.Store != nil {
+		vc.persist(licenseKeyFromCacheKey(key))
+	}
+}
+
+// refresh revalidates an entry in the background
+func (vc *validationCache) refresh(key string, fetch validationFetchFunc) {
+	_, _ = vc.fetch(context.Background(), key, fetch)
+}
+
+// offlineFallback returns the cached result for key if it is valid and
+// still within the offline grace period, restoring it from the Store if it
+// is not held in memory
+func (vc *validationCache) offlineFallback(key string) (*ValidationResult, bool) {
+	vc.mu.Lock()
+	entry, ok := vc.lookupLocked(key)
+	vc.mu.Unlock()
+	if !ok && vc.config.Store != nil {
+		vc.load(licenseKeyFromCacheKey(key))
+	}
+
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+	entry, ok = vc.lookupLocked(key)
+	if !ok || !entry.result.Valid {
+		return nil, false
+	}
+	result := entry.result.clone()
+	return &result, true
+}
+
+// lookupLocked returns the entry for key, dropping it if it has outlived
+// every window in which it could still be served
+func (vc *validationCache) lookupLocked(key string) (*validationCacheEntry, bool) {
+	elem, ok := vc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*validationCacheEntry)
+	if vc.expired(entry, vc.now()) {
+		vc.order.Remove(elem)
+		delete(vc.entries, key)
+		return nil, false
+	}
+	vc.order.MoveToFront(elem)
+	return entry, true
+}
+
+// storeLocked adds or replaces entry, evicting old entries to make room
+func (vc *validationCache) storeLocked(entry *validationCacheEntry) {
+	if elem, ok := vc.entries[entry.key]; ok {
+		elem.Value = entry
+		vc.order.MoveToFront(elem)
+		return
+	}
+	vc.evictLocked(vc.now())
+	vc.entries[entry.key] = vc.order.PushFront(entry)
+}
+
+// evictLocked drops expired entries, sweeping the whole cache at most once
+// per NegativeTTL, then the least recently used entries until there is room
+// for one more
+func (vc *validationCache) evictLocked(now time.Time) {
+	if now.Sub(vc.sweptAt) >= vc.config.NegativeTTL {
+		vc.sweptAt = now
+		for key, elem := range vc.entries {
+			if vc.expired(elem.Value.(*validationCacheEntry), now) {
+				vc.order.Remove(elem)
+				delete(vc.entries, key)
+			}
+		}
+	}
+	for vc.order.Len() >= vc.config.MaxEntries {
+		entry := vc.order.Remove(vc.order.Back()).(*validationCacheEntry)
+		delete(vc.entries, entry.key)
+	}
+}
+
+// expired reports whether entry can no longer be served, even as a stale
+// result or an offline fallback
+func (vc *validationCache) expired(entry *validationCacheEntry, now time.Time) bool {
+	retention := vc.ttl(&entry.result) + vc.config.StaleWhileRevalidate
+	if entry.result.Valid {
+		retention += vc.config.OfflineGracePeriod
+	}
+	return now.Sub(entry.fetchedAt) >= retention
+}
+
+// invalidate drops all entries for a license key
+func (vc *validationCache) invalidate(licenseKey string) {
+	prefix := licenseKey + "\x00"
+
+	vc.mu.Lock()
+	for key, elem := range vc.entries {
+		if strings.HasPrefix(key, prefix) {
+			vc.order.Remove(elem)
+			delete(vc.entries, key)
+		}
+	}
+	vc.mu.Unlock()
+
+	if vc.config.Store != nil {
+		vc.storeMu.Lock()
+		defer vc.storeMu.Unlock()
+		_ = vc.config.Store.Delete(validationStoreKey(licenseKey))
+	}
+}
+
+// load restores persisted entries for a license key into memory without
+// replacing newer ones. Entries dated in the future, e.g. after the clock
+// was wound back, are ignored.
+func (vc *validationCache) load(licenseKey string) {
+	vc.storeMu.Lock()
+	persisted := vc.readStoreLocked(licenseKey)
+	vc.storeMu.Unlock()
+
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+	now := vc.now()
+	prefix := licenseKey + "\x00"
+	for variant, p := range persisted {
+		if p.FetchedAt.After(now) {
+			continue
+		}
+		if _, exists := vc.entries[prefix+variant]; !exists {
+			entry := &validationCacheEntry{key: prefix + variant, result: p.Result, fetchedAt: p.FetchedAt}
+			if !vc.expired(entry, now) {
+				vc.storeLocked(entry)
+			}
+		}
+	}
+}
+
+// persist merges the in-memory entries for a license key into the Store
+func (vc *validationCache) persist(licenseKey string) {
+	vc.storeMu.Lock()
+	defer vc.storeMu.Unlock()
+
+	persisted := vc.readStoreLocked(licenseKey)
+	if persisted == nil {
+		persisted = make(map[string]persistedValidation)
+	}
+
+	prefix := licenseKey + "\x00"
+	vc.mu.Lock()
+	now := vc.now()
+	for variant, p := range persisted {
+		if vc.expired(&validationCacheEntry{result: p.Result, fetchedAt: p.FetchedAt}, now) {
+			delete(persisted, variant)
+		}
+	}
+	for key, elem := range vc.entries {
+		if strings.HasPrefix(key, prefix) {
+			entry := elem.Value.(*validationCacheEntry)
+			persisted[strings.TrimPrefix(key, prefix)] = persistedValidation{
+				Result:    entry.result,
+				FetchedAt: entry.fetchedAt,
+			}
+		}
+	}
+	vc.mu.Unlock()
+
+	data, err := json.Marshal(persisted)
+	if err != nil {
+		return
+	}
+	_ = vc.config.Store.Set(validationStoreKey(licenseKey), data)
+}
+
+// readStoreLocked returns the persisted entries for a license key, deleting
+// tampered or unreadable data. The caller must hold storeMu.
+func (vc *validationCache) readStoreLocked(licenseKey string) map[string]persistedValidation {
+	storeKey := validationStoreKey(licenseKey)
+	data, ok, err := vc.config.Store.Get(storeKey)
+	if err != nil {
+		if errors.Is(err, ErrCacheTampered) {
+			_ = vc.config.Store.Delete(storeKey)
+		}
+		return nil
+	}
+	if !ok {
+		return nil
+	}
+
+	var persisted map[string]persistedValidation
+	if err := json.Unmarshal(data, &persisted); err != nil {
+		_ = vc.config.Store.Delete(storeKey)
+		return nil
+	}
+	return persisted
+}
+
+// validationStoreKey is the Store key holding all results for a license key
+func validationStoreKey(licenseKey string) string {
+	return "validation:" + licenseKey
+}
+
+// licenseKeyFromCacheKey extracts the license key from a validationCacheKey
+func licenseKeyFromCacheKey(key string) string {
+	licenseKey, _, _ := strings.Cut(key, "\x00")
+	return licenseKey
+}
+
+func (vc *validationCache) ttl(result *ValidationResult) time.Duration {
+	if result.Valid {
+		return vc.config.TTL
+	}
+	return vc.config.NegativeTTL
+}
+
+// InvalidateLicense drops cached validation results for a license key so the
+// next validation contacts the API. It is a no-op without WithValidationCache.
+func (c *LicenseChainClient) InvalidateLicense(licenseKey string) {
+	if c.validationCache != nil {
+		c.validationCache.invalidate(licenseKey)
+	}
+}
+
+// clone returns a deep copy of r, so results handed to callers never share
+// memory with the cache
+func (r ValidationResult) clone() ValidationResult {
+	if r.License != nil {
+		license := r.License.clone()
+		r.License = &license
+	}
+	if r.Features != nil {
+		r.Features = append([]string(nil), r.Features...)
+	}
+	if r.ExpiresAt != nil {
+		expiresAt := *r.ExpiresAt
+		r.ExpiresAt = &expiresAt
+	}
+	if r.RemainingActivations != nil {
+		remaining := *r.RemainingActivations
+		r.RemainingActivations = &remaining
+	}
+	return r
+}
+
+// clone returns a deep copy of l
+func (l License) clone() License {
+	if l.ExpiresAt != nil {
+		expiresAt := *l.ExpiresAt
+		l.ExpiresAt = &expiresAt
+	}
+	if l.Metadata != nil {
+		l.Metadata = cloneJSONValue(l.Metadata).(map[string]interface{})
+	}
+	if l.Entitlements.Features != nil {
+		features := make(map[string]bool, len(l.Entitlements.Features))
+		for name, enabled := range l.Entitlements.Features {
+			features[name] = enabled
+		}
+		l.Entitlements.Features = features
+	}
+	if l.Entitlements.Quotas != nil {
+		quotas := make(map[string]int64, len(l.Entitlements.Quotas))
+		for name, quota := range l.Entitlements.Quotas {
+			quotas[name] = quota
+		}
+		l.Entitlements.Quotas = quotas
+	}
+	return l
+}
+
+// cloneJSONValue deep-copies the maps and slices of a decoded JSON value
+func cloneJSONValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		clone := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			clone[key] = cloneJSONValue(value)
+		}
+		return clone
+	case []interface{}:
+		clone := make([]interface{}, len(v))
+		for i, value := range v {
+			clone[i] = cloneJSONValue(value)
+		}
+		return clone
+	}
+	return v
+}
@@ -0,0 +1,156 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationCacheOfflineGraceOnTimeout(t *testing.T) {
+	var hang atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hang.Load() {
+			select {
+			case <-time.After(time.Second):
+			case <-r.Context().Done():
+			}
+			return
+		}
+		w.Write([]byte(`{"valid":true}`))
+	}))
+	defer srv.Close()
+
+	lc := NewClient("key",
+		WithBaseURL(srv.URL),
+		WithRetryPolicy(NoRetryPolicy()),
+		WithValidationCache(ValidationCacheConfig{
+			TTL:                10 * time.Millisecond,
+			OfflineGracePeriod: time.Hour,
+			RefreshTimeout:     200 * time.Millisecond,
+		}),
+	)
+
+	valid, err := lc.ValidateLicense("LICENSE")
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	hang.Store(true)
+	time.Sleep(20 * time.Millisecond)
+
+	valid, err = lc.ValidateLicense("LICENSE")
+	require.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestValidationCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	vc := newValidationCache(ValidationCacheConfig{MaxEntries: 2})
+	fetch := func(ctx context.Context) (*ValidationResult, error) {
+		return &ValidationResult{Valid: true}, nil
+	}
+
+	for _, key := range []string{"A", "B", "A", "C"} {
+		_, err := vc.get(context.Background(), ValidateLicenseRequest{LicenseKey: key}, fetch)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, vc.order.Len())
+	assert.Contains(t, vc.entries, validationCacheKey(ValidateLicenseRequest{LicenseKey: "A"}))
+	assert.Contains(t, vc.entries, validationCacheKey(ValidateLicenseRequest{LicenseKey: "C"}))
+}
+
+func TestValidationCacheDropsExpiredEntries(t *testing.T) {
+	now := time.Now()
+	vc := newValidationCache(ValidationCacheConfig{TTL: time.Minute, NegativeTTL: time.Second})
+	vc.now = func() time.Time { return now }
+	valid := func(ctx context.Context) (*ValidationResult, error) {
+		return &ValidationResult{Valid: true}, nil
+	}
+	invalid := func(ctx context.Context) (*ValidationResult, error) {
+		return &ValidationResult{Valid: false}, nil
+	}
+
+	_, err := vc.get(context.Background(), ValidateLicenseRequest{LicenseKey: "GOOD"}, valid)
+	require.NoError(t, err)
+	_, err = vc.get(context.Background(), ValidateLicenseRequest{LicenseKey: "BAD"}, invalid)
+	require.NoError(t, err)
+
+	// Inserting another entry evicts the expired negative result only
+	now = now.Add(2 * time.Second)
+	_, err = vc.get(context.Background(), ValidateLicenseRequest{LicenseKey: "OTHER"}, valid)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, vc.order.Len())
+	assert.NotContains(t, vc.entries, validationCacheKey(ValidateLicenseRequest{LicenseKey: "BAD"}))
+}
+
+func TestValidationCacheReturnsCopies(t *testing.T) {
+	vc := newValidationCache(ValidationCacheConfig{})
+	fetch := func(ctx context.Context) (*ValidationResult, error) {
+		return &ValidationResult{
+			Valid:    true,
+			Features: []string{"export"},
+			License: &License{
+				Metadata:     map[string]interface{}{"seats": []interface{}{"a"}},
+				Entitlements: LicenseEntitlements{Features: map[string]bool{"export": true}},
+			},
+		}, nil
+	}
+	req := ValidateLicenseRequest{LicenseKey: "LICENSE"}
+
+	first, err := vc.get(context.Background(), req, fetch)
+	require.NoError(t, err)
+	first.Features[0] = "changed"
+	first.License.Entitlements.Features["export"] = false
+	first.License.Metadata["seats"].([]interface{})[0] = "changed"
+
+	second, err := vc.get(context.Background(), req, fetch)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"export"}, second.Features)
+	assert.True(t, second.License.Entitlements.Features["export"])
+	assert.Equal(t, "a", second.License.Metadata["seats"].([]interface{})[0])
+}
+
+// countingCache counts Store reads
+type countingCache struct {
+	*MemoryCache
+	gets atomic.Int32
+}
+
+func (cc *countingCache) Get(key string) ([]byte, bool, error) {
+	cc.gets.Add(1)
+	return cc.MemoryCache.Get(key)
+}
+
+func TestValidationCacheStoreSurvivesRestart(t *testing.T) {
+	store := &countingCache{MemoryCache: NewMemoryCache()}
+	config := ValidationCacheConfig{TTL: time.Minute, OfflineGracePeriod: time.Hour, Store: store}
+	req := ValidateLicenseRequest{LicenseKey: "LICENSE"}
+
+	vc := newValidationCache(config)
+	_, err := vc.get(context.Background(), req, func(ctx context.Context) (*ValidationResult, error) {
+		return &ValidationResult{Valid: true}, nil
+	})
+	require.NoError(t, err)
+
+	// A restarted process serves the persisted result while the API is unreachable
+	restarted := newValidationCache(config)
+	result, err := restarted.get(context.Background(), req, func(ctx context.Context) (*ValidationResult, error) {
+		return nil, NewNetworkError(assert.AnError)
+	})
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+
+	// Misses that reach the API do not read the Store
+	reads := store.gets.Load()
+	_, err = restarted.get(context.Background(), ValidateLicenseRequest{LicenseKey: "OTHER"}, func(ctx context.Context) (*ValidationResult, error) {
+		return &ValidationResult{Valid: false}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, reads+1, store.gets.Load(), "only the persist merge should read the Store")
+}
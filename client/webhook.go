@@ -1,36 +1,113 @@
 package client
 
 import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
 	"fmt"
+	"sync"
 )
 
 // WebhookHandler handles webhook events
 type WebhookHandler struct {
-	secret    string
-	tolerance int64 // seconds
+	secrets     []string
+	publicKeys  []ed25519.PublicKey
+	allowLegacy bool
+	tolerance   int64 // seconds
+
+	signatureHeader string
+	timestampHeader string
+	maxBodyBytes    int64
+	seen            WebhookSeenStore
+	asyncConfig     *AsyncConfig
+	async           *webhookQueue
+
+	mu       sync.RWMutex
+	handlers map[string]WebhookEventHandler
+	unknown  WebhookEventHandler
 }
 
-// NewWebhookHandler creates a new webhook handler
-func NewWebhookHandler(secret string, tolerance int64) *WebhookHandler {
+// WebhookEventHandler handles a decoded webhook event
+type WebhookEventHandler func(ctx context.Context, event WebhookEvent) error
+
+// NewWebhookHandler creates a new webhook handler. An empty secret is
+// ignored, so Ed25519-only handlers can pass "" with WithEd25519PublicKeys.
+func NewWebhookHandler(secret string, tolerance int64, opts ...WebhookOption) *WebhookHandler {
 	if tolerance <= 0 {
 		tolerance = 300 // 5 minutes default
 	}
-	return &WebhookHandler{
-		secret:    secret,
-		tolerance: tolerance,
+	wh := &WebhookHandler{
+		secrets:         nonEmptySecrets(nil, secret),
+		allowLegacy:     true,
+		tolerance:       tolerance,
+		signatureHeader: DefaultWebhookSignatureHeader,
+		timestampHeader: DefaultWebhookTimestampHeader,
+		maxBodyBytes:    DefaultWebhookMaxBodyBytes,
+		seen:            NewMemorySeenStore(DefaultWebhookSeenCapacity),
+		handlers:        make(map[string]WebhookEventHandler),
+	}
+	for _, opt := range opts {
+		opt(wh)
+	}
+	if wh.asyncConfig != nil {
+		wh.startAsync(*wh.asyncConfig)
+	}
+	return wh
+}
+
+// SetSecrets replaces the accepted signing secrets. Keep the old secret
+// alongside the new one until every sender has been rotated. Empty secrets
+// are ignored.
+func (wh *WebhookHandler) SetSecrets(secrets ...string) {
+	wh.mu.Lock()
+	defer wh.mu.Unlock()
+	wh.secrets = nonEmptySecrets(nil, secrets...)
+}
+
+// nonEmptySecrets appends the non-empty secrets to dst. An empty HMAC key
+// would let anyone forge signatures, so it is never accepted.
+func nonEmptySecrets(dst []string, secrets ...string) []string {
+	for _, secret := range secrets {
+		if secret != "" {
+			dst = append(dst, secret)
+		}
 	}
+	return dst
 }
 
-// VerifySignature verifies a webhook signature
+// VerifySignature verifies a legacy hex HMAC or a versioned signature header.
+// It does not check the timestamp; use VerifyWebhook for that.
 func (wh *WebhookHandler) VerifySignature(payload, signature string) bool {
-	return VerifyWebhookSignature(payload, signature, wh.secret)
+	if isVersionedWebhookSignature(signature) {
+		header, err := ParseWebhookSignatureHeader(signature)
+		if err != nil {
+			return false
+		}
+		return wh.verifyVersionedSignature(payload, header)
+	}
+
+	wh.mu.RLock()
+	secrets := wh.secrets
+	allowLegacy := wh.allowLegacy
+	wh.mu.RUnlock()
+
+	if !allowLegacy {
+		return false
+	}
+	for _, secret := range secrets {
+		if secret != "" && VerifyWebhookSignature(payload, signature, secret) {
+			return true
+		}
+	}
+	return false
 }
 
-// VerifyTimestamp verifies a webhook timestamp
+// VerifyTimestamp verifies a webhook timestamp. Invalid or stale timestamps
+// fail with an authentication error, like a bad signature.
 func (wh *WebhookHandler) VerifyTimestamp(timestamp string) error {
 	webhookTime, err := ParseTimestamp(timestamp)
 	if err != nil {
-		return fmt.Errorf("invalid timestamp format: %v", err)
+		return NewAuthenticationError(fmt.Sprintf("invalid webhook timestamp format: %v", err))
 	}
 	
 	currentTime := GetCurrentTimestamp()
@@ -40,14 +117,35 @@ func (wh *WebhookHandler) VerifyTimestamp(timestamp string) error {
 	}
 	
 	if timeDiff > wh.tolerance {
-		return fmt.Errorf("webhook timestamp too old: %d seconds", timeDiff)
+		return NewAuthenticationError(fmt.Sprintf("webhook timestamp too old: %d seconds", timeDiff))
 	}
 	
 	return nil
 }
 
-// VerifyWebhook verifies a webhook
+// VerifyWebhook verifies a webhook. For versioned signatures the signed
+// timestamp is checked and timestamp may be empty; if given it must match.
 func (wh *WebhookHandler) VerifyWebhook(payload, signature, timestamp string) error {
+	if isVersionedWebhookSignature(signature) {
+		header, err := ParseWebhookSignatureHeader(signature)
+		if err != nil {
+			return NewAuthenticationError("Invalid webhook signature")
+		}
+		if timestamp != "" {
+			unix, err := parseWebhookTimestamp(timestamp)
+			if err != nil || unix != header.Timestamp {
+				return NewAuthenticationError("Webhook timestamp does not match signature")
+			}
+		}
+		if err := wh.verifyUnixTimestamp(header.Timestamp); err != nil {
+			return err
+		}
+		if !wh.verifyVersionedSignature(payload, header) {
+			return NewAuthenticationError("Invalid webhook signature")
+		}
+		return nil
+	}
+
 	if err := wh.VerifyTimestamp(timestamp); err != nil {
 		return err
 	}
@@ -59,8 +157,22 @@ func (wh *WebhookHandler) VerifyWebhook(payload, signature, timestamp string) er
 	return nil
 }
 
-// ProcessEvent processes a webhook event
+// ProcessEvent processes a webhook event. Events whose ID was already
+// processed within the replay window are ignored. The "id" field is not
+// covered by the signature on this path, so deduplication is best effort
+// and events without an ID are dispatched without it.
+//
+// Deprecated: ProcessEvent verifies a re-serialized copy of the event data,
+// which does not byte-match what the server signed. Use ProcessRaw with the
+// raw request body and headers instead.
 func (wh *WebhookHandler) ProcessEvent(eventData map[string]interface{}) error {
+	return wh.ProcessEventCtx(context.Background(), eventData)
+}
+
+// ProcessEventCtx processes a webhook event, passing ctx to the registered handler
+//
+// Deprecated: use ProcessRawCtx.
+func (wh *WebhookHandler) ProcessEventCtx(ctx context.Context, eventData map[string]interface{}) error {
 	payload, err := JSONSerialize(eventData["data"])
 	if err != nil {
 		return fmt.Errorf("invalid event data: %v", err)
@@ -84,129 +196,174 @@ func (wh *WebhookHandler) ProcessEvent(eventData map[string]interface{}) error {
 	if !ok {
 		return NewValidationError("Missing event type")
 	}
-	
-	switch eventType {
-	case "license.created":
-		return wh.handleLicenseCreated(eventData)
-	case "license.updated":
-		return wh.handleLicenseUpdated(eventData)
-	case "license.revoked":
-		return wh.handleLicenseRevoked(eventData)
-	case "license.expired":
-		return wh.handleLicenseExpired(eventData)
-	case "user.created":
-		return wh.handleUserCreated(eventData)
-	case "user.updated":
-		return wh.handleUserUpdated(eventData)
-	case "user.deleted":
-		return wh.handleUserDeleted(eventData)
-	case "product.created":
-		return wh.handleProductCreated(eventData)
-	case "product.updated":
-		return wh.handleProductUpdated(eventData)
-	case "product.deleted":
-		return wh.handleProductDeleted(eventData)
-	case "payment.completed":
-		return wh.handlePaymentCompleted(eventData)
-	case "payment.failed":
-		return wh.handlePaymentFailed(eventData)
-	case "payment.refunded":
-		return wh.handlePaymentRefunded(eventData)
-	default:
-		fmt.Printf("Unknown webhook event type: %s\n", eventType)
+
+	data, err := json.Marshal(eventData["data"])
+	if err != nil {
+		return fmt.Errorf("invalid event data: %v", err)
+	}
+	id, _ := eventData["id"].(string)
+
+	event := WebhookEvent{
+		ID:        id,
+		Type:      eventType,
+		Timestamp: timestamp,
+		Data:      data,
+	}
+	if id == "" {
+		// Legacy payloads may have no ID to deduplicate by
+		return wh.Dispatch(ctx, event)
+	}
+	return wh.handleVerified(ctx, event, wh.Dispatch)
+}
+
+// Dispatch calls the handler registered for the event's type. Events of a
+// type outside WebhookEvents without a registered handler go to the
+// OnUnknown handler; other unhandled events are ignored.
+func (wh *WebhookHandler) Dispatch(ctx context.Context, event WebhookEvent) error {
+	wh.mu.RLock()
+	handler, ok := wh.handlers[event.Type]
+	if !ok && !ValidateWebhookEvent(event.Type) {
+		handler = wh.unknown
+	}
+	wh.mu.RUnlock()
+
+	if handler == nil {
 		return nil
 	}
+	return handler(ctx, event)
 }
 
-// Event handlers
-func (wh *WebhookHandler) handleLicenseCreated(eventData map[string]interface{}) error {
-	id, _ := eventData["id"].(string)
-	fmt.Printf("License created: %s\n", id)
-	// Add custom logic for license created event
-	return nil
+// Handler registration
+
+// On registers fn for events of the given type, replacing any previous handler
+func (wh *WebhookHandler) On(eventType string, fn WebhookEventHandler) {
+	wh.mu.Lock()
+	defer wh.mu.Unlock()
+	wh.handlers[eventType] = fn
 }
 
-func (wh *WebhookHandler) handleLicenseUpdated(eventData map[string]interface{}) error {
-	id, _ := eventData["id"].(string)
-	fmt.Printf("License updated: %s\n", id)
-	// Add custom logic for license updated event
-	return nil
+// OnUnknown registers fn for events whose type is not in WebhookEvents and has no handler
+func (wh *WebhookHandler) OnUnknown(fn WebhookEventHandler) {
+	wh.mu.Lock()
+	defer wh.mu.Unlock()
+	wh.unknown = fn
 }
 
-func (wh *WebhookHandler) handleLicenseRevoked(eventData map[string]interface{}) error {
-	id, _ := eventData["id"].(string)
-	fmt.Printf("License revoked: %s\n", id)
-	// Add custom logic for license revoked event
-	return nil
+// OnLicenseCreated registers fn for license.created events
+func (wh *WebhookHandler) OnLicenseCreated(fn func(ctx context.Context, event LicenseEvent) error) {
+	wh.On(WebhookEvents.LicenseCreated, licenseEventHandler(fn))
 }
 
-func (wh *WebhookHandler) handleLicenseExpired(eventData map[string]interface{}) error {
-	id, _ := eventData["id"].(string)
-	fmt.Printf("License expired: %s\n", id)
-	// Add custom logic for license expired event
-	return nil
+// OnLicenseUpdated registers fn for license.updated events
+func (wh *WebhookHandler) OnLicenseUpdated(fn func(ctx context.Context, event LicenseEvent) error) {
+	wh.On(WebhookEvents.LicenseUpdated, licenseEventHandler(fn))
 }
 
-func (wh *WebhookHandler) handleUserCreated(eventData map[string]interface{}) error {
-	id, _ := eventData["id"].(string)
-	fmt.Printf("User created: %s\n", id)
-	// Add custom logic for user created event
-	return nil
+// OnLicenseRevoked registers fn for license.revoked events
+func (wh *WebhookHandler) OnLicenseRevoked(fn func(ctx context.Context, event LicenseEvent) error) {
+	wh.On(WebhookEvents.LicenseRevoked, licenseEventHandler(fn))
 }
 
-func (wh *WebhookHandler) handleUserUpdated(eventData map[string]interface{}) error {
-	id, _ := eventData["id"].(string)
-	fmt.Printf("User updated: %s\n", id)
-	// Add custom logic for user updated event
-	return nil
+// OnLicenseExpired registers fn for license.expired events
+func (wh *WebhookHandler) OnLicenseExpired(fn func(ctx context.Context, event LicenseEvent) error) {
+	wh.On(WebhookEvents.LicenseExpired, licenseEventHandler(fn))
 }
 
-func (wh *WebhookHandler) handleUserDeleted(eventData map[string]interface{}) error {
-	id, _ := eventData["id"].(string)
-	fmt.Printf("User deleted: %s\n", id)
-	// Add custom logic for user deleted event
-	return nil
+// OnUserCreated registers fn for user.created events
+func (wh *WebhookHandler) OnUserCreated(fn func(ctx context.Context, event UserEvent) error) {
+	wh.On(WebhookEvents.UserCreated, userEventHandler(fn))
 }
 
-func (wh *WebhookHandler) handleProductCreated(eventData map[string]interface{}) error {
-	id, _ := eventData["id"].(string)
-	fmt.Printf("Product created: %s\n", id)
-	// Add custom logic for product created event
-	return nil
+// OnUserUpdated registers fn for user.updated events
+func (wh *WebhookHandler) OnUserUpdated(fn func(ctx context.Context, event UserEvent) error) {
+	wh.On(WebhookEvents.UserUpdated, userEventHandler(fn))
 }
 
-func (wh *WebhookHandler) handleProductUpdated(eventData map[string]interface{}) error {
-	id, _ := eventData["id"].(string)
-	fmt.Printf("Product updated: %s\n", id)
-	// Add custom logic for product updated event
-	return nil
+// OnUserDeleted registers fn for user.deleted events
+func (wh *WebhookHandler) OnUserDeleted(fn func(ctx context.Context, event UserEvent) error) {
+	wh.On(WebhookEvents.UserDeleted, userEventHandler(fn))
 }
 
-func (wh *WebhookHandler) handleProductDeleted(eventData map[string]interface{}) error {
-	id, _ := eventData["id"].(string)
-	fmt.Printf("Product deleted: %s\n", id)
-	// Add custom logic for product deleted event
-	return nil
+// OnProductCreated registers fn for product.created events
+func (wh *WebhookHandler) OnProductCreated(fn func(ctx context.Context, event ProductEvent) error) {
+	wh.On(WebhookEvents.ProductCreated, productEventHandler(fn))
 }
 
-func (wh *WebhookHandler) handlePaymentCompleted(eventData map[string]interface{}) error {
-	id, _ := eventData["id"].(string)
-	fmt.Printf("Payment completed: %s\n", id)
-	// Add custom logic for payment completed event
-	return nil
+// OnProductUpdated registers fn for product.updated events
+func (wh *WebhookHandler) OnProductUpdated(fn func(ctx context.Context, event ProductEvent) error) {
+	wh.On(WebhookEvents.ProductUpdated, productEventHandler(fn))
 }
 
-func (wh *WebhookHandler) handlePaymentFailed(eventData map[string]interface{}) error {
-	id, _ := eventData["id"].(string)
-	fmt.Printf("Payment failed: %s\n", id)
-	// Add custom logic for payment failed event
-	return nil
+// OnProductDeleted registers fn for product.deleted events
+func (wh *WebhookHandler) OnProductDeleted(fn func(ctx context.Context, event ProductEvent) error) {
+	wh.On(WebhookEvents.ProductDeleted, productEventHandler(fn))
 }
 
-func (wh *WebhookHandler) handlePaymentRefunded(eventData map[string]interface{}) error {
-	id, _ := eventData["id"].(string)
-	fmt.Printf("Payment refunded: %s\n", id)
-	// Add custom logic for payment refunded event
+// OnPaymentCompleted registers fn for payment.completed events
+func (wh *WebhookHandler) OnPaymentCompleted(fn func(ctx context.Context, event PaymentEvent) error) {
+	wh.On(WebhookEvents.PaymentCompleted, paymentEventHandler(fn))
+}
+
+// OnPaymentFailed registers fn for payment.failed events
+func (wh *WebhookHandler) OnPaymentFailed(fn func(ctx context.Context, event PaymentEvent) error) {
+	wh.On(WebhookEvents.PaymentFailed, paymentEventHandler(fn))
+}
+
+// OnPaymentRefunded registers fn for payment.refunded events
+func (wh *WebhookHandler) OnPaymentRefunded(fn func(ctx context.Context, event PaymentEvent) error) {
+	wh.On(WebhookEvents.PaymentRefunded, paymentEventHandler(fn))
+}
+
+// Typed event decoding
+
+func licenseEventHandler(fn func(ctx context.Context, event LicenseEvent) error) WebhookEventHandler {
+	return func(ctx context.Context, event WebhookEvent) error {
+		typed := LicenseEvent{WebhookEvent: event}
+		if err := event.decodeData(&typed.License); err != nil {
+			return err
+		}
+		return fn(ctx, typed)
+	}
+}
+
+func userEventHandler(fn func(ctx context.Context, event UserEvent) error) WebhookEventHandler {
+	return func(ctx context.Context, event WebhookEvent) error {
+		typed := UserEvent{WebhookEvent: event}
+		if err := event.decodeData(&typed.User); err != nil {
+			return err
+		}
+		return fn(ctx, typed)
+	}
+}
+
+func productEventHandler(fn func(ctx context.Context, event ProductEvent) error) WebhookEventHandler {
+	return func(ctx context.Context, event WebhookEvent) error {
+		typed := ProductEvent{WebhookEvent: event}
+		if err := event.decodeData(&typed.Product); err != nil {
+			return err
+		}
+		return fn(ctx, typed)
+	}
+}
+
+func paymentEventHandler(fn func(ctx context.Context, event PaymentEvent) error) WebhookEventHandler {
+	return func(ctx context.Context, event WebhookEvent) error {
+		typed := PaymentEvent{WebhookEvent: event}
+		if err := event.decodeData(&typed.Payment); err != nil {
+			return err
+		}
+		return fn(ctx, typed)
+	}
+}
+
+// decodeData decodes the event payload into v
+func (e WebhookEvent) decodeData(v interface{}) error {
+	if len(e.Data) == 0 {
+		return NewValidationError(fmt.Sprintf("%s event has no data", e.Type))
+	}
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return NewValidationError(fmt.Sprintf("invalid %s event data: %v", e.Type, err))
+	}
 	return nil
 }
 
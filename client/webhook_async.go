@@ -0,0 +1,411 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AsyncConfig configures asynchronous webhook processing. Zero values use
+// the defaults noted on each field.
+type AsyncConfig struct {
+	// Workers is the number of events processed concurrently (default 4)
+	Workers int
+	// QueueSize is the number of accepted events waiting for a worker
+	// (default 100). Deliveries are rejected while the queue is full.
+	QueueSize int
+	// MaxAttempts is the number of times a handler is tried per event (default 5)
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry; it doubles per attempt (default 1s)
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries (default 1m)
+	MaxBackoff time.Duration
+	// DeadLetters receives events that failed every attempt. Defaults to a
+	// FileDeadLetterStore in DefaultDeadLetterDir, or memory if that
+	// directory cannot be created.
+	DeadLetters DeadLetterStore
+}
+
+// withDefaults fills in zero fields
+func (c AsyncConfig) withDefaults() AsyncConfig {
+	if c.Workers <= 0 {
+		c.Workers = 4
+	}
+	if c.QueueSize <= 0 {
+		c.QueueSize = 100
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = 5
+	}
+	if c.InitialBackoff <= 0 {
+		c.InitialBackoff = time.Second
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = time.Minute
+	}
+	if c.DeadLetters == nil {
+		if store, err := NewFileDeadLetterStore(DefaultDeadLetterDir()); err == nil {
+			c.DeadLetters = store
+		} else {
+			c.DeadLetters = NewMemoryDeadLetterStore()
+		}
+	}
+	return c
+}
+
+// DeadLetter is an event whose handler failed every attempt
+type DeadLetter struct {
+	Event     WebhookEvent `json:"event"`
+	Attempts  int          `json:"attempts"`
+	LastError string       `json:"last_error"`
+	FailedAt  time.Time    `json:"failed_at"`
+}
+
+// DeadLetterStore keeps failed webhook events for inspection and replay.
+// Implementations must be safe for concurrent use.
+type DeadLetterStore interface {
+	// Put stores letter, replacing any letter for the same event ID
+	Put(letter DeadLetter) error
+	// Get returns the letter for eventID and whether it was found
+	Get(eventID string) (*DeadLetter, bool, error)
+	// Delete removes the letter for eventID
+	Delete(eventID string) error
+	// List returns all stored letters, oldest first
+	List() ([]DeadLetter, error)
+}
+
+// MemoryDeadLetterStore is a DeadLetterStore held in process memory
+type MemoryDeadLetterStore struct {
+	mu      sync.Mutex
+	letters map[string]DeadLetter
+}
+
+// NewMemoryDeadLetterStore creates an empty in-memory dead-letter store
+func NewMemoryDeadLetterStore() *MemoryDeadLetterStore {
+	return &MemoryDeadLetterStore{letters: make(map[string]DeadLetter)}
+}
+
+// Put stores letter
+func (ms *MemoryDeadLetterStore) Put(letter DeadLetter) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.letters[letter.Event.ID] = letter
+	return nil
+}
+
+// Get returns the letter for eventID and whether it was found
+func (ms *MemoryDeadLetterStore) Get(eventID string) (*DeadLetter, bool, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	letter, ok := ms.letters[eventID]
+	if !ok {
+		return nil, false, nil
+	}
+	return &letter, true, nil
+}
+
+// Delete removes the letter for eventID
+func (ms *MemoryDeadLetterStore) Delete(eventID string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	delete(ms.letters, eventID)
+	return nil
+}
+
+// List returns all stored letters, oldest first
+func (ms *MemoryDeadLetterStore) List() ([]DeadLetter, error) {
+	ms.mu.Lock()
+	letters := make([]DeadLetter, 0, len(ms.letters))
+	for _, letter := range ms.letters {
+		letters = append(letters, letter)
+	}
+	ms.mu.Unlock()
+	sortDeadLetters(letters)
+	return letters, nil
+}
+
+// DefaultDeadLetterDir returns the directory used by the default file-backed dead-letter store
+func DefaultDeadLetterDir() string {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		base = os.TempDir()
+	}
+	return filepath.Join(base, "licensechain", "webhook-dead-letters")
+}
+
+// FileDeadLetterStore is a DeadLetterStore that writes each letter as a
+// JSON file in a directory, so failed events survive restarts
+type FileDeadLetterStore struct {
+	dir string
+}
+
+// NewFileDeadLetterStore creates a file-backed dead-letter store in dir, creating it if needed
+func NewFileDeadLetterStore(dir string) (*FileDeadLetterStore, error) {
+	if err := ValidateNotEmpty(dir, "dir"); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create dead-letter directory: %v", err)
+	}
+	return &FileDeadLetterStore{dir: dir}, nil
+}
+
+// Put atomically writes letter
+func (fs *FileDeadLetterStore) Put(letter DeadLetter) error {
+	data, err := json.Marshal(letter)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(fs.path(letter.Event.ID), data, 0600)
+}
+
+// Get returns the letter for eventID and whether it was found
+func (fs *FileDeadLetterStore) Get(eventID string) (*DeadLetter, bool, error) {
+	data, err := os.ReadFile(fs.path(eventID))
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	var letter DeadLetter
+	if err := json.Unmarshal(data, &letter); err != nil {
+		return nil, false, fmt.Errorf("invalid dead letter %s: %v", eventID, err)
+	}
+	return &letter, true, nil
+}
+
+// Delete removes the letter for eventID
+func (fs *FileDeadLetterStore) Delete(eventID string) error {
+	if err := os.Remove(fs.path(eventID)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// List returns all stored letters, oldest first
+func (fs *FileDeadLetterStore) List() ([]DeadLetter, error) {
+	entries, err := os.ReadDir(fs.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var letters []DeadLetter
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(fs.dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		var letter DeadLetter
+		if err := json.Unmarshal(data, &letter); err != nil {
+			continue
+		}
+		letters = append(letters, letter)
+	}
+	sortDeadLetters(letters)
+	return letters, nil
+}
+
+// path returns the file holding eventID; names are hashed so any ID is a safe file name
+func (fs *FileDeadLetterStore) path(eventID string) string {
+	sum := sha256.Sum256([]byte(eventID))
+	return filepath.Join(fs.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func sortDeadLetters(letters []DeadLetter) {
+	sort.Slice(letters, func(i, j int) bool {
+		return letters[i].FailedAt.Before(letters[j].FailedAt)
+	})
+}
+
+// webhookQueue is the bounded worker pool behind asynchronous processing
+type webhookQueue struct {
+	config AsyncConfig
+	policy RetryPolicy
+	jobs   chan WebhookEvent
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// startAsync starts the worker pool for config
+func (wh *WebhookHandler) startAsync(config AsyncConfig) {
+	config = config.withDefaults()
+	ctx, cancel := context.WithCancel(context.Background())
+	q := &webhookQueue{
+		config: config,
+		policy: RetryPolicy{
+			MaxAttempts:  config.MaxAttempts,
+			InitialDelay: config.InitialBackoff,
+			MaxDelay:     config.MaxBackoff,
+			Jitter:       0.2,
+		},
+		jobs:   make(chan WebhookEvent, config.QueueSize),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	for i := 0; i < config.Workers; i++ {
+		q.wg.Add(1)
+		go wh.runWorker(q)
+	}
+	wh.async = q
+}
+
+// enqueue accepts event for asynchronous processing without blocking
+func (wh *WebhookHandler) enqueue(ctx context.Context, event WebhookEvent) error {
+	q := wh.async
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		return ErrWebhookClosed
+	}
+	select {
+	case q.jobs <- event:
+		return nil
+	default:
+		return ErrWebhookQueueFull
+	}
+}
+
+// runWorker processes queued events until the queue is closed
+func (wh *WebhookHandler) runWorker(q *webhookQueue) {
+	defer q.wg.Done()
+	for event := range q.jobs {
+		wh.processWithRetry(q, event)
+	}
+}
+
+// processWithRetry dispatches event, retrying with backoff, and moves it to
+// the dead-letter store once every attempt has failed or the handler is
+// shut down. A panicking handler counts as a failed attempt. The ID of a
+// dead-lettered event is forgotten by the seen store, so a redelivery from
+// the sender is processed again; a redelivery that succeeds removes the
+// dead letter.
+func (wh *WebhookHandler) processWithRetry(q *webhookQueue, event WebhookEvent) {
+	var err error
+	attempts := 0
+	for attempts < q.config.MaxAttempts {
+		if attempts > 0 {
+			if sleepErr := SleepCtx(q.ctx, q.policy.Backoff(attempts-1, nil)); sleepErr != nil {
+				break
+			}
+		}
+		attempts++
+		if err = wh.dispatchRecover(q.ctx, event); err == nil {
+			if event.ID != "" {
+				_ = q.config.DeadLetters.Delete(event.ID)
+			}
+			return
+		}
+	}
+
+	_ = q.config.DeadLetters.Put(DeadLetter{
+		Event:     event,
+		Attempts:  attempts,
+		LastError: err.Error(),
+		FailedAt:  time.Now().UTC(),
+	})
+	if wh.seen != nil && event.ID != "" {
+		_ = wh.seen.Forget(event.ID)
+	}
+}
+
+// dispatchRecover calls Dispatch, turning a handler panic into an error so
+// that it cannot kill the worker goroutine
+func (wh *WebhookHandler) dispatchRecover(ctx context.Context, event WebhookEvent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("webhook handler panicked: %v", r)
+		}
+	}()
+	return wh.Dispatch(ctx, event)
+}
+
+// DeadLetters returns the dead-letter store used in asynchronous mode, or
+// nil if asynchronous processing is not enabled
+func (wh *WebhookHandler) DeadLetters() DeadLetterStore {
+	if wh.async == nil {
+		return nil
+	}
+	return wh.async.config.DeadLetters
+}
+
+// Replay reprocesses a dead-lettered event synchronously with the
+// registered handlers. On success the event is removed from the store; on
+// failure its attempt count and last error are updated.
+func (wh *WebhookHandler) Replay(ctx context.Context, eventID string) error {
+	store := wh.DeadLetters()
+	if store == nil {
+		return NewValidationError("asynchronous processing is not enabled")
+	}
+
+	letter, found, err := store.Get(eventID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return NewNotFoundError(fmt.Sprintf("dead letter %s", eventID))
+	}
+
+	if err := wh.Dispatch(ctx, letter.Event); err != nil {
+		letter.Attempts++
+		letter.LastError = err.Error()
+		letter.FailedAt = time.Now().UTC()
+		_ = store.Put(*letter)
+		return err
+	}
+	return store.Delete(eventID)
+}
+
+// Close stops accepting events and waits for queued events to finish processing
+func (wh *WebhookHandler) Close() error {
+	return wh.Shutdown(context.Background())
+}
+
+// Shutdown stops accepting events and waits for queued events to finish
+// processing. If ctx is done first, Shutdown returns ctx.Err() without
+// waiting further; pending retries are abandoned and their events are
+// moved to the dead-letter store in the background.
+func (wh *WebhookHandler) Shutdown(ctx context.Context) error {
+	q := wh.async
+	if q == nil {
+		return nil
+	}
+
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.jobs)
+	}
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		q.cancel()
+		return nil
+	case <-ctx.Done():
+		q.cancel()
+		return ctx.Err()
+	}
+}
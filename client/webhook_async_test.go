@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signedWebhookHeaders returns headers carrying a v1 signature of body
+func signedWebhookHeaders(t *testing.T, body, secret string) http.Header {
+	t.Helper()
+	signature, err := CreateWebhookSignatureHeader(body, secret, time.Now().Unix())
+	require.NoError(t, err)
+	headers := http.Header{}
+	headers.Set(DefaultWebhookSignatureHeader, signature)
+	return headers
+}
+
+func newAsyncTestHandler(store DeadLetterStore) *WebhookHandler {
+	return NewWebhookHandler("secret", 300, WithAsyncProcessing(AsyncConfig{
+		Workers:        1,
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		DeadLetters:    store,
+	}))
+}
+
+func TestAsyncWebhookRetriesUntilSuccess(t *testing.T) {
+	store := NewMemoryDeadLetterStore()
+	wh := newAsyncTestHandler(store)
+	var calls atomic.Int32
+	wh.On(WebhookEvents.LicenseCreated, func(ctx context.Context, event WebhookEvent) error {
+		if calls.Add(1) < 3 {
+			return errors.New("temporary failure")
+		}
+		return nil
+	})
+
+	require.NoError(t, wh.ProcessRaw([]byte(testWebhookPayload), signedWebhookHeaders(t, testWebhookPayload, "secret")))
+	require.NoError(t, wh.Close())
+
+	assert.Equal(t, int32(3), calls.Load())
+	letters, err := store.List()
+	require.NoError(t, err)
+	assert.Empty(t, letters)
+}
+
+func TestAsyncWebhookPanicIsDeadLetteredAndRedeliverable(t *testing.T) {
+	store := NewMemoryDeadLetterStore()
+	wh := newAsyncTestHandler(store)
+	var fixed atomic.Bool
+	wh.On(WebhookEvents.LicenseCreated, func(ctx context.Context, event WebhookEvent) error {
+		if !fixed.Load() {
+			panic("handler bug")
+		}
+		return nil
+	})
+	headers := signedWebhookHeaders(t, testWebhookPayload, "secret")
+
+	require.NoError(t, wh.ProcessRaw([]byte(testWebhookPayload), headers))
+	require.Eventually(t, func() bool {
+		_, found, _ := store.Get("evt_1")
+		return found
+	}, 2*time.Second, 5*time.Millisecond)
+
+	letter, _, err := store.Get("evt_1")
+	require.NoError(t, err)
+	assert.Equal(t, 3, letter.Attempts)
+	assert.Contains(t, letter.LastError, "handler bug")
+
+	// The sender's redelivery is processed again and clears the dead letter
+	fixed.Store(true)
+	require.NoError(t, wh.ProcessRaw([]byte(testWebhookPayload), headers))
+	require.NoError(t, wh.Close())
+	_, found, err := store.Get("evt_1")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestAsyncWebhookShutdownHonorsContext(t *testing.T) {
+	store := NewMemoryDeadLetterStore()
+	wh := newAsyncTestHandler(store)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	wh.On(WebhookEvents.LicenseCreated, func(ctx context.Context, event WebhookEvent) error {
+		close(started)
+		<-release
+		return nil
+	})
+
+	require.NoError(t, wh.ProcessRaw([]byte(testWebhookPayload), signedWebhookHeaders(t, testWebhookPayload, "secret")))
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := wh.Shutdown(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+
+	next := `{"id":"evt_2","type":"license.created","data":{}}`
+	assert.ErrorIs(t, wh.ProcessRaw([]byte(next), signedWebhookHeaders(t, next, "secret")), ErrWebhookClosed)
+}
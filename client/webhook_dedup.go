@@ -0,0 +1,171 @@
+package client
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// WebhookSeenStore records the IDs of in-progress and processed webhook
+// events so that replayed or redelivered events are handled only once.
+// Implementations backed by shared storage (e.g. Redis) let several
+// receiver instances deduplicate together. Implementations must be safe for
+// concurrent use.
+type WebhookSeenStore interface {
+	// MarkSeen records id as in progress for ttl unless it is already
+	// recorded, and returns the state it had before the call
+	MarkSeen(id string, ttl time.Duration) (WebhookSeenState, error)
+	// MarkProcessed records that the event with id was handled successfully
+	MarkProcessed(id string, ttl time.Duration) error
+	// Forget removes id so that a redelivery of the event is processed again
+	Forget(id string) error
+}
+
+// WebhookSeenState is the deduplication state of an event ID
+type WebhookSeenState int
+
+const (
+	// WebhookUnseen means the ID was not recorded
+	WebhookUnseen WebhookSeenState = iota
+	// WebhookInProgress means a delivery of the event is being handled
+	WebhookInProgress
+	// WebhookProcessed means the event was handled successfully
+	WebhookProcessed
+)
+
+// DefaultWebhookSeenCapacity is the number of event IDs remembered by the default seen store
+const DefaultWebhookSeenCapacity = 10000
+
+// MemorySeenStore is an in-memory WebhookSeenStore that remembers up to a
+// fixed number of event IDs, evicting expired and then least recently
+// recorded IDs first
+type MemorySeenStore struct {
+	capacity int
+	now      func() time.Time
+
+	mu    sync.Mutex
+	order *list.List
+	items map[string]*list.Element
+}
+
+type seenEntry struct {
+	id        string
+	state     WebhookSeenState
+	expiresAt time.Time
+}
+
+// NewMemorySeenStore creates an in-memory seen store holding up to capacity IDs
+func NewMemorySeenStore(capacity int) *MemorySeenStore {
+	if capacity <= 0 {
+		capacity = DefaultWebhookSeenCapacity
+	}
+	return &MemorySeenStore{
+		capacity: capacity,
+		now:      time.Now,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// MarkSeen records id as in progress for ttl unless it is already
+// recorded, and returns the state it had before the call
+func (s *MemorySeenStore) MarkSeen(id string, ttl time.Duration) (WebhookSeenState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if elem, ok := s.items[id]; ok {
+		entry := elem.Value.(*seenEntry)
+		if now.Before(entry.expiresAt) {
+			return entry.state, nil
+		}
+		s.order.Remove(elem)
+		delete(s.items, id)
+	}
+
+	s.evictLocked(now)
+	s.items[id] = s.order.PushFront(&seenEntry{id: id, state: WebhookInProgress, expiresAt: now.Add(ttl)})
+	return WebhookUnseen, nil
+}
+
+// MarkProcessed records id as processed for ttl
+func (s *MemorySeenStore) MarkProcessed(id string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if elem, ok := s.items[id]; ok {
+		entry := elem.Value.(*seenEntry)
+		entry.state = WebhookProcessed
+		entry.expiresAt = now.Add(ttl)
+		s.order.MoveToFront(elem)
+		return nil
+	}
+
+	s.evictLocked(now)
+	s.items[id] = s.order.PushFront(&seenEntry{id: id, state: WebhookProcessed, expiresAt: now.Add(ttl)})
+	return nil
+}
+
+// Forget removes id
+func (s *MemorySeenStore) Forget(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elem, ok := s.items[id]; ok {
+		s.order.Remove(elem)
+		delete(s.items, id)
+	}
+	return nil
+}
+
+// evictLocked drops expired entries from the back of the list, then the
+// oldest entries until there is room for one more
+func (s *MemorySeenStore) evictLocked(now time.Time) {
+	for elem := s.order.Back(); elem != nil; {
+		prev := elem.Prev()
+		entry := elem.Value.(*seenEntry)
+		if !now.Before(entry.expiresAt) || s.order.Len() >= s.capacity {
+			s.order.Remove(elem)
+			delete(s.items, entry.id)
+		} else {
+			break
+		}
+		elem = prev
+	}
+}
+
+// handleVerified deduplicates a verified event by ID and passes it to
+// handle. A duplicate of an event still being handled fails with
+// ErrWebhookInProgress so the sender retries it later. If handle fails, the
+// ID is forgotten so a redelivery is retried.
+func (wh *WebhookHandler) handleVerified(ctx context.Context, event WebhookEvent, handle WebhookEventHandler) error {
+	if wh.seen == nil {
+		return handle(ctx, event)
+	}
+
+	if event.ID == "" {
+		return NewValidationError("Missing event id")
+	}
+	// Timestamps are accepted within ±tolerance, so a replay can only
+	// succeed within twice the tolerance of the original delivery
+	ttl := 2 * time.Duration(wh.tolerance) * time.Second
+	state, err := wh.seen.MarkSeen(event.ID, ttl)
+	if err != nil {
+		return err
+	}
+	switch state {
+	case WebhookProcessed:
+		return nil
+	case WebhookInProgress:
+		return ErrWebhookInProgress
+	}
+
+	if err := handle(ctx, event); err != nil {
+		_ = wh.seen.Forget(event.ID)
+		return err
+	}
+	_ = wh.seen.MarkProcessed(event.ID, ttl)
+	return nil
+}
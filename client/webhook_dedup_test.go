@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemorySeenStore(t *testing.T) {
+	now := time.Now()
+	store := NewMemorySeenStore(2)
+	store.now = func() time.Time { return now }
+
+	state, err := store.MarkSeen("a", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, WebhookUnseen, state)
+
+	state, _ = store.MarkSeen("a", time.Minute)
+	assert.Equal(t, WebhookInProgress, state)
+
+	require.NoError(t, store.MarkProcessed("a", time.Minute))
+	state, _ = store.MarkSeen("a", time.Minute)
+	assert.Equal(t, WebhookProcessed, state)
+
+	require.NoError(t, store.Forget("a"))
+	state, _ = store.MarkSeen("a", time.Minute)
+	assert.Equal(t, WebhookUnseen, state)
+
+	// Expired IDs are seen as new
+	now = now.Add(2 * time.Minute)
+	state, _ = store.MarkSeen("a", time.Minute)
+	assert.Equal(t, WebhookUnseen, state)
+
+	// The oldest ID is evicted beyond capacity
+	store.MarkSeen("b", time.Minute)
+	store.MarkSeen("c", time.Minute)
+	state, _ = store.MarkSeen("a", time.Minute)
+	assert.Equal(t, WebhookUnseen, state)
+}
+
+func TestWebhookDeduplication(t *testing.T) {
+	wh := NewWebhookHandler("secret", 300)
+	calls := 0
+	fail := true
+	wh.On(WebhookEvents.LicenseCreated, func(ctx context.Context, event WebhookEvent) error {
+		calls++
+		if fail {
+			return errors.New("handler failed")
+		}
+		return nil
+	})
+	deliver := func() error {
+		return wh.ProcessRaw([]byte(testWebhookPayload), signedWebhookHeaders(t, testWebhookPayload, "secret"))
+	}
+
+	// A failed event is processed again when redelivered
+	assert.Error(t, deliver())
+	fail = false
+	assert.NoError(t, deliver())
+	// A processed event is acknowledged without calling the handler
+	assert.NoError(t, deliver())
+	assert.Equal(t, 2, calls)
+
+	// Events without an ID cannot be deduplicated on the raw path
+	body := `{"type":"license.created","data":{}}`
+	assert.ErrorIs(t, wh.ProcessRaw([]byte(body), signedWebhookHeaders(t, body, "secret")), ErrValidationError)
+}
+
+func TestWebhookDuplicateOfInFlightEvent(t *testing.T) {
+	wh := NewWebhookHandler("secret", 300)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	wh.On(WebhookEvents.LicenseCreated, func(ctx context.Context, event WebhookEvent) error {
+		close(started)
+		<-release
+		return errors.New("handler failed")
+	})
+	send := func() *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(testWebhookPayload))
+		r.Header = signedWebhookHeaders(t, testWebhookPayload, "secret")
+		w := httptest.NewRecorder()
+		wh.ServeHTTP(w, r)
+		return w
+	}
+
+	var wg sync.WaitGroup
+	var first *httptest.ResponseRecorder
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		first = send()
+	}()
+	<-started
+
+	duplicate := send()
+	assert.Equal(t, http.StatusConflict, duplicate.Code)
+	assert.NotEmpty(t, duplicate.Header().Get("Retry-After"))
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, http.StatusInternalServerError, first.Code)
+}
+
+func TestProcessEventWithoutID(t *testing.T) {
+	wh := NewWebhookHandler("secret", 300)
+	calls := 0
+	wh.On(WebhookEvents.LicenseCreated, func(ctx context.Context, event WebhookEvent) error {
+		calls++
+		return nil
+	})
+
+	data := map[string]interface{}{"id": "lic_1"}
+	payload, err := JSONSerialize(data)
+	require.NoError(t, err)
+	event := map[string]interface{}{
+		"type":      WebhookEvents.LicenseCreated,
+		"data":      data,
+		"signature": CreateWebhookSignature(payload, "secret"),
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	require.NoError(t, wh.ProcessEvent(event))
+	require.NoError(t, wh.ProcessEvent(event))
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,214 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// Default webhook delivery headers and limits
+const (
+	DefaultWebhookSignatureHeader = "X-LicenseChain-Signature"
+	DefaultWebhookTimestampHeader = "X-LicenseChain-Timestamp"
+	DefaultWebhookMaxBodyBytes    = 1 << 20 // 1 MiB
+)
+
+type webhookEventContextKey struct{}
+
+// WebhookEventFromContext returns the verified event stored by WebhookHandler.Middleware
+func WebhookEventFromContext(ctx context.Context) (WebhookEvent, bool) {
+	event, ok := ctx.Value(webhookEventContextKey{}).(WebhookEvent)
+	return event, ok
+}
+
+// ServeHTTP receives a webhook delivery: it reads the raw body, verifies the
+// signature and timestamp headers, and dispatches the event to the
+// registered handlers. Redeliveries of an already processed event are
+// acknowledged without calling handlers again, while a redelivery of an
+// event still being handled gets 409 so the sender retries it. Malformed
+// requests get 400, failed verification 401, oversized bodies 413 and
+// handler failures 500. In asynchronous mode queued events get 202 and a
+// full queue 503.
+func (wh *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	event, _, status, err := wh.readRequest(w, r)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	if wh.async != nil {
+		if err := wh.handleVerified(r.Context(), event, wh.enqueue); err != nil {
+			if errors.Is(err, ErrValidationError) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if errors.Is(err, ErrWebhookInProgress) {
+				writeWebhookInProgress(w, err)
+				return
+			}
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	if err := wh.handleVerified(r.Context(), event, wh.Dispatch); err != nil {
+		if errors.Is(err, ErrValidationError) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, ErrWebhookInProgress) {
+			writeWebhookInProgress(w, err)
+			return
+		}
+		http.Error(w, "webhook handler failed", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// Middleware verifies webhook deliveries before passing them to next. The
+// request body is restored for next to read, and the decoded event is
+// available through WebhookEventFromContext. Registered handlers are not
+// called. Redeliveries of an event that next already handled are
+// acknowledged without calling next, and redeliveries of one it is still
+// handling get 409; if next responds with a 5xx status the event may be
+// delivered again.
+func (wh *WebhookHandler) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		event, body, status, err := wh.readRequest(w, r)
+		if err != nil {
+			http.Error(w, err.Error(), status)
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		r = r.WithContext(context.WithValue(r.Context(), webhookEventContextKey{}, event))
+
+		err = wh.handleVerified(r.Context(), event, func(ctx context.Context, event WebhookEvent) error {
+			recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(recorder, r)
+			if recorder.status >= 500 {
+				return errNextHandlerFailed
+			}
+			return nil
+		})
+		if errors.Is(err, ErrValidationError) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		} else if errors.Is(err, ErrWebhookInProgress) {
+			writeWebhookInProgress(w, err)
+		} else if err != nil && err != errNextHandlerFailed {
+			http.Error(w, "webhook handler failed", http.StatusInternalServerError)
+		}
+	})
+}
+
+// writeWebhookInProgress answers a duplicate of an event still being handled
+// with a retryable status
+func writeWebhookInProgress(w http.ResponseWriter, err error) {
+	w.Header().Set("Retry-After", "1")
+	http.Error(w, err.Error(), http.StatusConflict)
+}
+
+// errNextHandlerFailed marks a 5xx response already written by a middleware's next handler
+var errNextHandlerFailed = errors.New("next handler failed")
+
+// statusRecorder captures the status code written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (sr *statusRecorder) WriteHeader(status int) {
+	sr.status = status
+	sr.ResponseWriter.WriteHeader(status)
+}
+
+// readRequest reads and verifies a delivery, returning the decoded event,
+// the raw body, and the HTTP status to respond with on error
+func (wh *WebhookHandler) readRequest(w http.ResponseWriter, r *http.Request) (WebhookEvent, []byte, int, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, wh.maxBodyBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return WebhookEvent{}, nil, http.StatusRequestEntityTooLarge, NewValidationError("request body too large")
+		}
+		return WebhookEvent{}, nil, http.StatusBadRequest, NewValidationError("failed to read request body")
+	}
+
+	event, err := wh.ParseRaw(body, r.Header)
+	if err != nil {
+		if errors.Is(err, ErrAuthenticationError) {
+			return event, body, http.StatusUnauthorized, err
+		}
+		return event, body, http.StatusBadRequest, err
+	}
+	return event, body, http.StatusOK, nil
+}
+
+// VerifyRaw verifies the signature headers against the exact request body
+// bytes. The timestamp header is only required for legacy signatures.
+func (wh *WebhookHandler) VerifyRaw(body []byte, headers http.Header) error {
+	signature := headers.Get(wh.signatureHeader)
+	if signature == "" {
+		return NewValidationError("Missing signature")
+	}
+	// Versioned signatures carry a signed timestamp of their own.
+	timestamp := headers.Get(wh.timestampHeader)
+	if timestamp == "" && !isVersionedWebhookSignature(signature) {
+		return NewValidationError("Missing timestamp")
+	}
+
+	return wh.VerifyWebhook(string(body), signature, timestamp)
+}
+
+// ParseRaw verifies a raw delivery with VerifyRaw and decodes the event
+func (wh *WebhookHandler) ParseRaw(body []byte, headers http.Header) (WebhookEvent, error) {
+	var event WebhookEvent
+	if err := wh.VerifyRaw(body, headers); err != nil {
+		return event, err
+	}
+
+	if err := json.Unmarshal(body, &event); err != nil {
+		return event, NewValidationError("invalid event payload")
+	}
+	if event.Type == "" {
+		return event, NewValidationError("Missing event type")
+	}
+	if event.Timestamp == "" {
+		event.Timestamp = headers.Get(wh.timestampHeader)
+	}
+	return event, nil
+}
+
+// ProcessRaw verifies, decodes and dispatches a raw delivery
+func (wh *WebhookHandler) ProcessRaw(body []byte, headers http.Header) error {
+	return wh.ProcessRawCtx(context.Background(), body, headers)
+}
+
+// ProcessRawCtx verifies, decodes and dispatches a raw delivery using the
+// provided context. Events already processed within the replay window are
+// ignored, and an event still being processed fails with
+// ErrWebhookInProgress. In asynchronous mode the event is queued instead,
+// failing with ErrWebhookQueueFull if there is no room.
+func (wh *WebhookHandler) ProcessRawCtx(ctx context.Context, body []byte, headers http.Header) error {
+	event, err := wh.ParseRaw(body, headers)
+	if err != nil {
+		return err
+	}
+	if wh.async != nil {
+		return wh.handleVerified(ctx, event, wh.enqueue)
+	}
+	return wh.handleVerified(ctx, event, wh.Dispatch)
+}
@@ -0,0 +1,191 @@
+package client
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Webhook signature schemes used in versioned signature headers
+const (
+	// WebhookSchemeHMACSHA256 is a hex HMAC-SHA256 signature
+	WebhookSchemeHMACSHA256 = "v1"
+	// WebhookSchemeHMACSHA512 is a hex HMAC-SHA512 signature
+	WebhookSchemeHMACSHA512 = "v1_sha512"
+	// WebhookSchemeEd25519 is a base64 Ed25519 signature
+	WebhookSchemeEd25519 = "v1_ed25519"
+)
+
+// WebhookSignatureHeader is a parsed versioned signature header of the form
+// "t=<unix seconds>,v1=<signature>[,<scheme>=<signature>...]". Every
+// signature covers "<t>.<payload>", so the timestamp cannot be altered
+// without invalidating it.
+type WebhookSignatureHeader struct {
+	Timestamp  int64
+	Signatures map[string][]string
+}
+
+// ParseWebhookSignatureHeader parses a versioned signature header
+func ParseWebhookSignatureHeader(header string) (*WebhookSignatureHeader, error) {
+	parsed := &WebhookSignatureHeader{Signatures: make(map[string][]string)}
+	hasTimestamp := false
+
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok || value == "" {
+			return nil, NewValidationError("malformed signature header")
+		}
+		if key == "t" {
+			timestamp, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, NewValidationError("malformed signature timestamp")
+			}
+			parsed.Timestamp = timestamp
+			hasTimestamp = true
+			continue
+		}
+		parsed.Signatures[key] = append(parsed.Signatures[key], value)
+	}
+
+	if !hasTimestamp {
+		return nil, NewValidationError("signature header has no timestamp")
+	}
+	if len(parsed.Signatures) == 0 {
+		return nil, NewValidationError("signature header has no signatures")
+	}
+	return parsed, nil
+}
+
+// String formats the header with schemes in a stable order
+func (h *WebhookSignatureHeader) String() string {
+	schemes := make([]string, 0, len(h.Signatures))
+	for scheme := range h.Signatures {
+		schemes = append(schemes, scheme)
+	}
+	sort.Strings(schemes)
+
+	parts := []string{"t=" + strconv.FormatInt(h.Timestamp, 10)}
+	for _, scheme := range schemes {
+		for _, signature := range h.Signatures[scheme] {
+			parts = append(parts, scheme+"="+signature)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// isVersionedWebhookSignature reports whether signature is a versioned header rather than a legacy hex HMAC
+func isVersionedWebhookSignature(signature string) bool {
+	return strings.HasPrefix(strings.TrimSpace(signature), "t=")
+}
+
+// webhookSignedContent returns the bytes covered by a versioned signature
+func webhookSignedContent(payload string, timestamp int64) string {
+	return strconv.FormatInt(timestamp, 10) + "." + payload
+}
+
+// SignWebhookPayload signs "<timestamp>.<payload>" with an HMAC scheme
+func SignWebhookPayload(scheme, payload, secret string, timestamp int64) (string, error) {
+	var newHash func() hash.Hash
+	switch scheme {
+	case WebhookSchemeHMACSHA256:
+		return CreateWebhookSignature(webhookSignedContent(payload, timestamp), secret), nil
+	case WebhookSchemeHMACSHA512:
+		newHash = sha512.New
+	default:
+		return "", NewValidationError(fmt.Sprintf("unsupported signature scheme: %s", scheme))
+	}
+
+	h := hmac.New(newHash, []byte(secret))
+	h.Write([]byte(webhookSignedContent(payload, timestamp)))
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// SignWebhookPayloadEd25519 signs "<timestamp>.<payload>" with an Ed25519 private key
+func SignWebhookPayloadEd25519(payload string, key ed25519.PrivateKey, timestamp int64) string {
+	signature := ed25519.Sign(key, []byte(webhookSignedContent(payload, timestamp)))
+	return base64.StdEncoding.EncodeToString(signature)
+}
+
+// CreateWebhookSignatureHeader creates a versioned signature header for
+// payload at the given Unix timestamp. Schemes default to v1 (HMAC-SHA256).
+func CreateWebhookSignatureHeader(payload, secret string, timestamp int64, schemes ...string) (string, error) {
+	if len(schemes) == 0 {
+		schemes = []string{WebhookSchemeHMACSHA256}
+	}
+
+	header := &WebhookSignatureHeader{Timestamp: timestamp, Signatures: make(map[string][]string)}
+	for _, scheme := range schemes {
+		signature, err := SignWebhookPayload(scheme, payload, secret, timestamp)
+		if err != nil {
+			return "", err
+		}
+		header.Signatures[scheme] = append(header.Signatures[scheme], signature)
+	}
+	return header.String(), nil
+}
+
+// verifyVersionedSignature checks a parsed header against every configured
+// secret and public key. One valid signature of any accepted scheme suffices.
+func (wh *WebhookHandler) verifyVersionedSignature(payload string, header *WebhookSignatureHeader) bool {
+	wh.mu.RLock()
+	secrets := wh.secrets
+	publicKeys := wh.publicKeys
+	wh.mu.RUnlock()
+
+	signed := webhookSignedContent(payload, header.Timestamp)
+	for scheme, signatures := range header.Signatures {
+		for _, signature := range signatures {
+			switch scheme {
+			case WebhookSchemeHMACSHA256, WebhookSchemeHMACSHA512:
+				for _, secret := range secrets {
+					if secret == "" {
+						continue
+					}
+					expected, _ := SignWebhookPayload(scheme, payload, secret, header.Timestamp)
+					if hmac.Equal([]byte(strings.ToLower(signature)), []byte(expected)) {
+						return true
+					}
+				}
+			case WebhookSchemeEd25519:
+				raw, err := base64.StdEncoding.DecodeString(signature)
+				if err != nil {
+					continue
+				}
+				for _, key := range publicKeys {
+					if ed25519.Verify(key, []byte(signed), raw) {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// verifyUnixTimestamp checks a Unix timestamp against the tolerance window
+func (wh *WebhookHandler) verifyUnixTimestamp(timestamp int64) error {
+	timeDiff := time.Now().Unix() - timestamp
+	if timeDiff < 0 {
+		timeDiff = -timeDiff
+	}
+	if timeDiff > wh.tolerance {
+		return NewAuthenticationError(fmt.Sprintf("webhook timestamp outside tolerance: %d seconds", timeDiff))
+	}
+	return nil
+}
+
+// parseWebhookTimestamp parses a timestamp given as RFC 3339 or Unix seconds
+func parseWebhookTimestamp(timestamp string) (int64, error) {
+	if unix, err := strconv.ParseInt(timestamp, 10, 64); err == nil {
+		return unix, nil
+	}
+	return ParseTimestamp(timestamp)
+}
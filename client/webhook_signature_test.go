@@ -0,0 +1,105 @@
+package client
+
+import (
+	"crypto/ed25519"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testWebhookPayload = `{"id":"evt_1","type":"license.created","data":{}}`
+
+func TestParseWebhookSignatureHeader(t *testing.T) {
+	header, err := ParseWebhookSignatureHeader("t=1700000000,v1=abc,v1=def,v1_sha512=ghi")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1700000000), header.Timestamp)
+	assert.Equal(t, []string{"abc", "def"}, header.Signatures["v1"])
+	assert.Equal(t, "t=1700000000,v1=abc,v1=def,v1_sha512=ghi", header.String())
+
+	for _, malformed := range []string{"v1=abc", "t=1700000000", "t=abc,v1=abc", "t=1700000000,v1"} {
+		_, err := ParseWebhookSignatureHeader(malformed)
+		assert.ErrorIs(t, err, ErrValidationError, malformed)
+	}
+}
+
+func TestWebhookHandlerVerifyWebhook(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	now := time.Now().Unix()
+
+	sign := func(secret string, schemes ...string) string {
+		header, err := CreateWebhookSignatureHeader(testWebhookPayload, secret, now, schemes...)
+		require.NoError(t, err)
+		return header
+	}
+	ed25519Header := (&WebhookSignatureHeader{
+		Timestamp:  now,
+		Signatures: map[string][]string{WebhookSchemeEd25519: {SignWebhookPayloadEd25519(testWebhookPayload, privateKey, now)}},
+	}).String()
+	staleHeader, err := CreateWebhookSignatureHeader(testWebhookPayload, "new", now-3600)
+	require.NoError(t, err)
+	rfc3339 := time.Unix(now, 0).UTC().Format(time.RFC3339)
+
+	tests := []struct {
+		name      string
+		handler   *WebhookHandler
+		signature string
+		timestamp string
+		wantErr   bool
+	}{
+		{"v1", NewWebhookHandler("new", 300), sign("new"), "", false},
+		{"v1_sha512", NewWebhookHandler("new", 300), sign("new", WebhookSchemeHMACSHA512), "", false},
+		{"wrong secret", NewWebhookHandler("new", 300), sign("old"), "", true},
+		{"rotated secret", NewWebhookHandler("new", 300, WithSecrets("old")), sign("old"), "", false},
+		{"replaced secret", func() *WebhookHandler {
+			wh := NewWebhookHandler("old", 300)
+			wh.SetSecrets("new")
+			return wh
+		}(), sign("old"), "", true},
+		{"empty secret", NewWebhookHandler("", 300), sign(""), "", true},
+		{"empty rotation secret", NewWebhookHandler("new", 300, WithSecrets("")), sign(""), "", true},
+		{"ed25519", NewWebhookHandler("", 300, WithEd25519PublicKeys(publicKey)), ed25519Header, "", false},
+		{"ed25519 without key", NewWebhookHandler("new", 300), ed25519Header, "", true},
+		{"ed25519 bad key length", NewWebhookHandler("", 300, WithEd25519PublicKeys(ed25519.PublicKey("short"))), ed25519Header, "", true},
+		{"stale", NewWebhookHandler("new", 300), staleHeader, "", true},
+		{"mismatched timestamp header", NewWebhookHandler("new", 300), sign("new"), "1", true},
+		{"legacy", NewWebhookHandler("new", 300), CreateWebhookSignature(testWebhookPayload, "new"), rfc3339, false},
+		{"legacy empty secret", NewWebhookHandler("", 300), CreateWebhookSignature(testWebhookPayload, ""), rfc3339, true},
+		{"legacy disabled", NewWebhookHandler("new", 300, WithLegacySignatures(false)), CreateWebhookSignature(testWebhookPayload, "new"), rfc3339, true},
+		{"legacy stale", NewWebhookHandler("new", 300), CreateWebhookSignature(testWebhookPayload, "new"), "2001-01-01T00:00:00Z", true},
+		{"legacy bad timestamp", NewWebhookHandler("new", 300), CreateWebhookSignature(testWebhookPayload, "new"), "yesterday", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.handler.VerifyWebhook(testWebhookPayload, tt.signature, tt.timestamp)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrAuthenticationError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWebhookHandlerRejectsBadEd25519KeyOverHTTP(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	now := time.Now().Unix()
+	header := (&WebhookSignatureHeader{
+		Timestamp:  now,
+		Signatures: map[string][]string{WebhookSchemeEd25519: {SignWebhookPayloadEd25519(testWebhookPayload, privateKey, now)}},
+	}).String()
+
+	wh := NewWebhookHandler("", 300, WithEd25519PublicKeys(ed25519.PublicKey("short")))
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(testWebhookPayload))
+	r.Header.Set(DefaultWebhookSignatureHeader, header)
+	w := httptest.NewRecorder()
+	wh.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"io"
+)
+
+// runHealth prints the API health status
+func runHealth(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("health", "", stderr)
+	out := addOutputFlag(fs)
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	lc, err := newClient()
+	if err != nil {
+		return err
+	}
+	health, err := lc.HealthCtx(ctx)
+	if err != nil {
+		return err
+	}
+	return out.print(stdout, health, func() table {
+		return table{
+			headers: []string{"STATUS", "VERSION", "TIMESTAMP"},
+			rows:    [][]string{{health.Status, orDash(health.Version), orDash(health.Timestamp)}},
+		}
+	})
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+
+	"github.com/licensechain/licensechain-go-sdk/client"
+)
+
+var licenseCommands = []command{
+	{"create", "Create a license", licensesCreate},
+	{"get", "Show a license", licensesGet},
+	{"list", "List licenses", licensesList},
+	{"validate", "Validate a license key", licensesValidate},
+	{"revoke", "Revoke a license", licensesRevoke},
+}
+
+// runLicenses dispatches the licenses subcommands
+func runLicenses(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	return runGroup(ctx, "licensechain licenses", licenseCommands, args, stdout, stderr)
+}
+
+func licensesCreate(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var req client.CreateLicenseRequest
+	metadata := metadataFlag{}
+	fs := newFlagSet("licenses create", "", stderr)
+	fs.StringVar(&req.UserID, "user-id", "", "ID of the user the license is issued to (required)")
+	fs.StringVar(&req.ProductID, "product-id", "", "ID of the licensed product (required)")
+	fs.IntVar(&req.MaxSeats, "max-seats", 0, "maximum concurrent activations (default unlimited)")
+	fs.Var(metadata, "metadata", "metadata as key=value (repeatable)")
+	out := addOutputFlag(fs)
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+	if len(metadata) > 0 {
+		req.Metadata = metadata
+	}
+
+	lc, err := newClient()
+	if err != nil {
+		return err
+	}
+	license, err := lc.CreateLicenseCtx(ctx, req)
+	if err != nil {
+		return err
+	}
+	return out.print(stdout, license, func() table { return licenseTable(*license) })
+}
+
+func licensesGet(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("licenses get", "<license-id>", stderr)
+	out := addOutputFlag(fs)
+	positional, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	lc, err := newClient()
+	if err != nil {
+		return err
+	}
+	license, err := lc.GetLicenseCtx(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+	return out.print(stdout, license, func() table { return licenseTable(*license) })
+}
+
+func licensesList(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var req client.ListLicensesRequest
+	fs := newFlagSet("licenses list", "", stderr)
+	fs.IntVar(&req.Page, "page", 1, "page number")
+	fs.IntVar(&req.Limit, "limit", 10, "licenses per page")
+	fs.StringVar(&req.Status, "status", "", "only licenses with this status")
+	fs.StringVar(&req.UserID, "user-id", "", "only licenses of this user")
+	fs.StringVar(&req.ProductID, "product-id", "", "only licenses for this product")
+	out := addOutputFlag(fs)
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	lc, err := newClient()
+	if err != nil {
+		return err
+	}
+	licenses, err := lc.ListLicensesCtx(ctx, req)
+	if err != nil {
+		return err
+	}
+	return out.print(stdout, licenses, func() table { return licenseTable(licenses.Data...) })
+}
+
+func licensesValidate(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var productID, deviceID string
+	fs := newFlagSet("licenses validate", "<license-key>", stderr)
+	fs.StringVar(&productID, "product-id", "", "require the license to be for this product")
+	fs.StringVar(&deviceID, "device-id", "", "validate for this device")
+	out := addOutputFlag(fs)
+	positional, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	var opts []client.ValidateOption
+	if productID != "" {
+		opts = append(opts, client.WithProductID(productID))
+	}
+	if deviceID != "" {
+		opts = append(opts, client.WithDeviceID(deviceID))
+	}
+
+	lc, err := newClient()
+	if err != nil {
+		return err
+	}
+	result, err := lc.ValidateLicenseDetailedCtx(ctx, positional[0], opts...)
+	if err != nil {
+		return err
+	}
+	if err := out.print(stdout, result, func() table {
+		return table{
+			headers: []string{"VALID", "REASON", "EXPIRES", "REMAINING ACTIVATIONS"},
+			rows: [][]string{{
+				strconv.FormatBool(result.Valid),
+				orDash(result.Reason),
+				formatTimePtr(result.ExpiresAt),
+				formatIntPtr(result.RemainingActivations),
+			}},
+		}
+	}); err != nil {
+		return err
+	}
+
+	// Exit non-zero so scripts can check validity
+	if !result.Valid {
+		return errors.New("license is not valid")
+	}
+	return nil
+}
+
+func licensesRevoke(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var reason string
+	fs := newFlagSet("licenses revoke", "<license-id>", stderr)
+	fs.StringVar(&reason, "reason", "", "reason recorded with the revocation")
+	out := addOutputFlag(fs)
+	positional, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	lc, err := newClient()
+	if err != nil {
+		return err
+	}
+	license, err := lc.RevokeLicenseCtx(ctx, positional[0], reason)
+	if err != nil {
+		return err
+	}
+	return out.print(stdout, license, func() table { return licenseTable(*license) })
+}
+
+// licenseTable renders licenses as table rows
+func licenseTable(licenses ...client.License) table {
+	t := table{headers: []string{"ID", "KEY", "STATUS", "USER", "PRODUCT", "SEATS", "EXPIRES"}}
+	for _, license := range licenses {
+		seats := "-"
+		if license.MaxSeats > 0 {
+			seats = strconv.Itoa(license.UsedSeats) + "/" + strconv.Itoa(license.MaxSeats)
+		}
+		t.rows = append(t.rows, []string{
+			license.ID,
+			license.LicenseKey,
+			license.Status,
+			license.UserID,
+			license.ProductID,
+			seats,
+			formatTimePtr(license.ExpiresAt),
+		})
+	}
+	return t
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/licensechain/licensechain-go-sdk/client"
+)
+
+// listenConfig holds the flags of the listen command
+type listenConfig struct {
+	forwardTo       string
+	secret          string
+	secretStdin     bool
+	events          string
+	from            string
+	interval        time.Duration
+	legacy          bool
+	signatureHeader string
+	timestampHeader string
+}
+
+// runListen polls account events and forwards each one, re-signed with the
+// local secret, to a local webhook endpoint until ctx is cancelled
+func runListen(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	cfg := listenConfig{secret: os.Getenv("LICENSECHAIN_WEBHOOK_SECRET")}
+	fs := newFlagSet("listen", "", stderr)
+	fs.StringVar(&cfg.forwardTo, "forward-to", "", "local URL to POST events to (required)")
+	fs.BoolVar(&cfg.secretStdin, "secret-stdin", false, "read the secret to sign events with from standard input (default $LICENSECHAIN_WEBHOOK_SECRET)")
+	fs.StringVar(&cfg.events, "events", "", "comma-separated event types to forward (default all)")
+	fs.StringVar(&cfg.from, "from", "", "forward events after this event ID instead of only new ones")
+	fs.DurationVar(&cfg.interval, "interval", 2*time.Second, "polling interval")
+	fs.BoolVar(&cfg.legacy, "legacy", false, "sign with a legacy hex HMAC and RFC 3339 timestamp header")
+	fs.StringVar(&cfg.signatureHeader, "signature-header", client.DefaultWebhookSignatureHeader, "signature header name")
+	fs.StringVar(&cfg.timestampHeader, "timestamp-header", client.DefaultWebhookTimestampHeader, "timestamp header name")
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	if cfg.forwardTo == "" {
+		return errors.New("--forward-to is required")
+	}
+	if !client.IsValidURL(cfg.forwardTo) {
+		return fmt.Errorf("invalid --forward-to URL: %s", cfg.forwardTo)
+	}
+	if cfg.secretStdin {
+		secret, err := readSecret()
+		if err != nil {
+			return err
+		}
+		cfg.secret = secret
+	}
+	if cfg.secret == "" {
+		return errors.New("LICENSECHAIN_WEBHOOK_SECRET or --secret-stdin is required")
+	}
+	if cfg.interval <= 0 {
+		return errors.New("--interval must be positive")
+	}
+
+	lc, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	req := client.ListEventsRequest{After: cfg.from, Types: splitList(cfg.events)}
+
+	// Without --from, skip the backlog and forward only new events
+	if cfg.from == "" {
+		if req.After, err = latestCursor(ctx, lc, req); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(stdout, "Forwarding events to %s (Ctrl-C to stop)\n", cfg.forwardTo)
+
+	forwarder := &http.Client{Timeout: 30 * time.Second}
+	ticker := time.NewTicker(cfg.interval)
+	defer ticker.Stop()
+
+	for {
+		req.After, err = forwardPending(ctx, lc, forwarder, cfg, req, stdout)
+		if err != nil && ctx.Err() == nil {
+			fmt.Fprintf(stderr, "licensechain: failed to fetch events: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// latestCursor returns the ID of the newest existing event, so that only
+// events after it are forwarded
+func latestCursor(ctx context.Context, lc *client.LicenseChainClient, req client.ListEventsRequest) (string, error) {
+	req.Order = "desc"
+	req.Limit = 1
+	page, err := lc.ListEventsCtx(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch events: %v", err)
+	}
+	if len(page.Data) == 0 {
+		return req.After, nil
+	}
+	return page.Data[0].ID, nil
+}
+
+// forwardPending forwards every event after req.After and returns the new cursor
+func forwardPending(ctx context.Context, lc *client.LicenseChainClient, forwarder *http.Client, cfg listenConfig, req client.ListEventsRequest, stdout io.Writer) (string, error) {
+	for {
+		page, err := lc.ListEventsCtx(ctx, req)
+		if err != nil {
+			return req.After, err
+		}
+		for _, event := range page.Data {
+			if ctx.Err() != nil {
+				return req.After, ctx.Err()
+			}
+			forwardEvent(ctx, forwarder, cfg, event, stdout)
+			req.After = event.ID
+		}
+		req.After = nextCursor(page, req.After)
+		if !page.HasMore {
+			return req.After, nil
+		}
+	}
+}
+
+// nextCursor returns the cursor following page
+func nextCursor(page *client.EventListResponse, current string) string {
+	if page.NextCursor != "" {
+		return page.NextCursor
+	}
+	if len(page.Data) > 0 {
+		return page.Data[len(page.Data)-1].ID
+	}
+	return current
+}
+
+// forwardEvent signs event with the local secret, POSTs it and prints the outcome
+func forwardEvent(ctx context.Context, forwarder *http.Client, cfg listenConfig, event client.WebhookEvent, stdout io.Writer) {
+	prefix := fmt.Sprintf("%s  %-24s %s", time.Now().Format("15:04:05"), event.Type, event.ID)
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		fmt.Fprintf(stdout, "%s  [error] %v\n", prefix, err)
+		return
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.forwardTo, bytes.NewReader(body))
+	if err != nil {
+		fmt.Fprintf(stdout, "%s  [error] %v\n", prefix, err)
+		return
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", client.DefaultUserAgent)
+
+	if cfg.legacy {
+		httpReq.Header.Set(cfg.signatureHeader, client.CreateWebhookSignature(string(body), cfg.secret))
+		httpReq.Header.Set(cfg.timestampHeader, time.Now().UTC().Format(time.RFC3339))
+	} else {
+		now := time.Now().Unix()
+		signature, err := client.CreateWebhookSignatureHeader(string(body), cfg.secret, now)
+		if err != nil {
+			fmt.Fprintf(stdout, "%s  [error] %v\n", prefix, err)
+			return
+		}
+		httpReq.Header.Set(cfg.signatureHeader, signature)
+		httpReq.Header.Set(cfg.timestampHeader, strconv.FormatInt(now, 10))
+	}
+
+	start := time.Now()
+	resp, err := forwarder.Do(httpReq)
+	if err != nil {
+		fmt.Fprintf(stdout, "%s  [error] %v\n", prefix, err)
+		return
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	fmt.Fprintf(stdout, "%s  -> [%d] %s (%s)\n", prefix, resp.StatusCode, http.StatusText(resp.StatusCode), time.Since(start).Round(time.Millisecond))
+}
@@ -0,0 +1,188 @@
+// Command licensechain is a command-line tool for the LicenseChain API.
+//
+// Credentials are read from the LICENSECHAIN_API_KEY and
+// LICENSECHAIN_BASE_URL environment variables.
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/licensechain/licensechain-go-sdk/client"
+)
+
+// command is a named subcommand
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, args []string, stdout, stderr io.Writer) error
+}
+
+var commands = []command{
+	{"licenses", "Create, inspect, validate and revoke licenses", runLicenses},
+	{"users", "Manage users", runUsers},
+	{"products", "Manage products", runProducts},
+	{"webhooks", "Manage webhook endpoints", runWebhooks},
+	{"listen", "Forward account events to a local webhook endpoint", runListen},
+	{"health", "Check API health", runHealth},
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	os.Exit(run(ctx, os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command in args and returns the process exit code
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	err := runGroup(ctx, "licensechain", commands, args, stdout, stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+	if errors.Is(err, errUsage) {
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "licensechain: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// errUsage reports invalid usage; the details have already been printed
+var errUsage = errors.New("invalid usage")
+
+// runGroup dispatches args to the matching command in cmds
+func runGroup(ctx context.Context, name string, cmds []command, args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		printGroupUsage(stderr, name, cmds)
+		return errUsage
+	}
+
+	switch args[0] {
+	case "help", "-h", "--help":
+		printGroupUsage(stdout, name, cmds)
+		return nil
+	}
+
+	for _, cmd := range cmds {
+		if cmd.name == args[0] {
+			return cmd.run(ctx, args[1:], stdout, stderr)
+		}
+	}
+
+	fmt.Fprintf(stderr, "%s: unknown command %q\n\n", name, args[0])
+	printGroupUsage(stderr, name, cmds)
+	return errUsage
+}
+
+// printGroupUsage lists the commands of a group
+func printGroupUsage(w io.Writer, name string, cmds []command) {
+	fmt.Fprintf(w, "Usage: %s <command> [flags]\n\nCommands:\n", name)
+	for _, cmd := range cmds {
+		fmt.Fprintf(w, "  %-14s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintf(w, "\nRun \"%s <command> -h\" for command flags.\n", name)
+}
+
+// newFlagSet creates a flag set for a command taking the given positional arguments
+func newFlagSet(name, positional string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: licensechain %s [flags]", name)
+		if positional != "" {
+			fmt.Fprintf(stderr, " %s", positional)
+		}
+		fmt.Fprint(stderr, "\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseArgs parses flags that may appear before or after positional
+// arguments, and checks that exactly want positional arguments were given
+func parseArgs(fs *flag.FlagSet, args []string, want int) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, errUsage
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if len(positional) != want {
+		fmt.Fprintf(fs.Output(), "expected %d argument(s), got %d\n", want, len(positional))
+		fs.Usage()
+		return nil, errUsage
+	}
+	return positional, nil
+}
+
+// newClient creates an API client from the environment
+func newClient() (*client.LicenseChainClient, error) {
+	if os.Getenv("LICENSECHAIN_API_KEY") == "" {
+		return nil, errors.New("LICENSECHAIN_API_KEY is not set")
+	}
+	return client.FromEnvironment(), nil
+}
+
+// stdin is where secrets are read from
+var stdin io.Reader = os.Stdin
+
+// readSecret reads a secret from the first line of standard input, so it
+// does not appear in the process list or shell history
+func readSecret() (string, error) {
+	line, err := bufio.NewReader(stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read secret from standard input: %v", err)
+	}
+	secret := strings.TrimSpace(line)
+	if secret == "" {
+		return "", errors.New("no secret on standard input")
+	}
+	return secret, nil
+}
+
+// metadataFlag collects repeated key=value flags into metadata
+type metadataFlag map[string]interface{}
+
+func (m metadataFlag) String() string {
+	return ""
+}
+
+func (m metadataFlag) Set(value string) error {
+	key, val, ok := strings.Cut(value, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("expected key=value, got %q", value)
+	}
+	m[key] = val
+	return nil
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
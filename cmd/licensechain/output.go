@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Output formats accepted by --output
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+// outputFlag is the value of --output
+type outputFlag string
+
+func (o *outputFlag) String() string {
+	return string(*o)
+}
+
+func (o *outputFlag) Set(value string) error {
+	switch value {
+	case outputTable, outputJSON, outputYAML:
+		*o = outputFlag(value)
+		return nil
+	}
+	return fmt.Errorf("must be one of %s, %s or %s", outputTable, outputJSON, outputYAML)
+}
+
+// addOutputFlag registers --output (and -o) on fs
+func addOutputFlag(fs *flag.FlagSet) *outputFlag {
+	out := outputFlag(outputTable)
+	fs.Var(&out, "output", "output format: table, json or yaml")
+	fs.Var(&out, "o", "shorthand for --output")
+	return &out
+}
+
+// table is the tabular rendering of a result
+type table struct {
+	headers []string
+	rows    [][]string
+}
+
+// print writes v in the selected format; tbl renders it for table output
+func (o outputFlag) print(w io.Writer, v interface{}, tbl func() table) error {
+	switch o {
+	case outputJSON:
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	case outputYAML:
+		return writeYAML(w, v)
+	}
+
+	t := tbl()
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(t.headers, "\t"))
+	for _, row := range t.rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// formatTime formats t for table output
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
+
+// formatTimePtr formats an optional time for table output
+func formatTimePtr(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return formatTime(*t)
+}
+
+// formatIntPtr formats an optional number for table output
+func formatIntPtr(n *int) string {
+	if n == nil {
+		return "-"
+	}
+	return strconv.Itoa(*n)
+}
+
+// orDash returns s, or "-" if it is empty
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// deleted is the result of a delete command
+type deleted struct {
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+// printDeleted reports that the resource with id was deleted
+func (o outputFlag) printDeleted(w io.Writer, id string) error {
+	result := deleted{ID: id, Deleted: true}
+	return o.print(w, result, func() table {
+		return table{headers: []string{"ID", "DELETED"}, rows: [][]string{{id, "true"}}}
+	})
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"io"
+	"strconv"
+
+	"github.com/licensechain/licensechain-go-sdk/client"
+)
+
+var productCommands = []command{
+	{"create", "Create a product", productsCreate},
+	{"get", "Show a product", productsGet},
+	{"list", "List products", productsList},
+	{"update", "Update a product", productsUpdate},
+	{"delete", "Delete a product", productsDelete},
+	{"licenses", "List a product's licenses", productsLicenses},
+	{"stats", "Show product statistics", productsStats},
+}
+
+// runProducts dispatches the products subcommands
+func runProducts(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	return runGroup(ctx, "licensechain products", productCommands, args, stdout, stderr)
+}
+
+func productsCreate(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var req client.CreateProductRequest
+	metadata := metadataFlag{}
+	fs := newFlagSet("products create", "", stderr)
+	fs.StringVar(&req.Name, "name", "", "product name (required)")
+	fs.StringVar(&req.Description, "description", "", "product description")
+	fs.Float64Var(&req.Price, "price", 0, "price")
+	fs.StringVar(&req.Currency, "currency", "USD", "ISO 4217 currency code")
+	fs.Var(metadata, "metadata", "metadata as key=value (repeatable)")
+	out := addOutputFlag(fs)
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+	if len(metadata) > 0 {
+		req.Metadata = metadata
+	}
+
+	lc, err := newClient()
+	if err != nil {
+		return err
+	}
+	product, err := lc.CreateProductCtx(ctx, req)
+	if err != nil {
+		return err
+	}
+	return out.print(stdout, product, func() table { return productTable(*product) })
+}
+
+func productsGet(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("products get", "<product-id>", stderr)
+	out := addOutputFlag(fs)
+	positional, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	lc, err := newClient()
+	if err != nil {
+		return err
+	}
+	product, err := lc.GetProductCtx(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+	return out.print(stdout, product, func() table { return productTable(*product) })
+}
+
+func productsList(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var page, limit int
+	fs := newFlagSet("products list", "", stderr)
+	fs.IntVar(&page, "page", 1, "page number")
+	fs.IntVar(&limit, "limit", 10, "products per page")
+	out := addOutputFlag(fs)
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	lc, err := newClient()
+	if err != nil {
+		return err
+	}
+	products, err := lc.ListProductsCtx(ctx, page, limit)
+	if err != nil {
+		return err
+	}
+	return out.print(stdout, products, func() table { return productTable(products.Data...) })
+}
+
+func productsUpdate(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var req client.UpdateProductRequest
+	metadata := metadataFlag{}
+	fs := newFlagSet("products update", "<product-id>", stderr)
+	fs.StringVar(&req.Name, "name", "", "new product name")
+	fs.StringVar(&req.Description, "description", "", "new product description")
+	fs.Float64Var(&req.Price, "price", 0, "new price")
+	fs.StringVar(&req.Currency, "currency", "", "new ISO 4217 currency code")
+	fs.Var(metadata, "metadata", "metadata as key=value (repeatable)")
+	out := addOutputFlag(fs)
+	positional, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if len(metadata) > 0 {
+		req.Metadata = metadata
+	}
+
+	lc, err := newClient()
+	if err != nil {
+		return err
+	}
+	product, err := lc.UpdateProductCtx(ctx, positional[0], req)
+	if err != nil {
+		return err
+	}
+	return out.print(stdout, product, func() table { return productTable(*product) })
+}
+
+func productsDelete(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("products delete", "<product-id>", stderr)
+	out := addOutputFlag(fs)
+	positional, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	lc, err := newClient()
+	if err != nil {
+		return err
+	}
+	if err := lc.DeleteProductCtx(ctx, positional[0]); err != nil {
+		return err
+	}
+	return out.printDeleted(stdout, positional[0])
+}
+
+func productsLicenses(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var page, limit int
+	fs := newFlagSet("products licenses", "<product-id>", stderr)
+	fs.IntVar(&page, "page", 1, "page number")
+	fs.IntVar(&limit, "limit", 10, "licenses per page")
+	out := addOutputFlag(fs)
+	positional, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	lc, err := newClient()
+	if err != nil {
+		return err
+	}
+	licenses, err := lc.ListProductLicensesCtx(ctx, positional[0], page, limit)
+	if err != nil {
+		return err
+	}
+	return out.print(stdout, licenses, func() table { return licenseTable(licenses.Data...) })
+}
+
+func productsStats(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("products stats", "", stderr)
+	out := addOutputFlag(fs)
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	lc, err := newClient()
+	if err != nil {
+		return err
+	}
+	stats, err := lc.GetProductStatsCtx(ctx)
+	if err != nil {
+		return err
+	}
+	return out.print(stdout, stats, func() table {
+		return table{
+			headers: []string{"TOTAL", "ACTIVE", "REVENUE"},
+			rows:    [][]string{{strconv.Itoa(stats.Total), strconv.Itoa(stats.Active), strconv.FormatFloat(stats.Revenue, 'f', 2, 64)}},
+		}
+	})
+}
+
+// productTable renders products as table rows
+func productTable(products ...client.Product) table {
+	t := table{headers: []string{"ID", "NAME", "PRICE", "CREATED"}}
+	for _, product := range products {
+		t.rows = append(t.rows, []string{
+			product.ID,
+			product.Name,
+			strconv.FormatFloat(product.Price, 'f', 2, 64) + " " + product.Currency,
+			formatTime(product.CreatedAt),
+		})
+	}
+	return t
+}
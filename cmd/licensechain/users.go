@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"io"
+	"strconv"
+
+	"github.com/licensechain/licensechain-go-sdk/client"
+)
+
+var userCommands = []command{
+	{"create", "Create a user", usersCreate},
+	{"get", "Show a user by ID or email", usersGet},
+	{"list", "List users", usersList},
+	{"update", "Update a user", usersUpdate},
+	{"delete", "Delete a user", usersDelete},
+	{"licenses", "List a user's licenses", usersLicenses},
+	{"stats", "Show user statistics", usersStats},
+}
+
+// runUsers dispatches the users subcommands
+func runUsers(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	return runGroup(ctx, "licensechain users", userCommands, args, stdout, stderr)
+}
+
+func usersCreate(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var req client.CreateUserRequest
+	metadata := metadataFlag{}
+	fs := newFlagSet("users create", "", stderr)
+	fs.StringVar(&req.Email, "email", "", "email address (required)")
+	fs.StringVar(&req.Name, "name", "", "display name (required)")
+	fs.Var(metadata, "metadata", "metadata as key=value (repeatable)")
+	out := addOutputFlag(fs)
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+	if len(metadata) > 0 {
+		req.Metadata = metadata
+	}
+
+	lc, err := newClient()
+	if err != nil {
+		return err
+	}
+	user, err := lc.CreateUserCtx(ctx, req)
+	if err != nil {
+		return err
+	}
+	return out.print(stdout, user, func() table { return userTable(*user) })
+}
+
+func usersGet(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("users get", "<user-id|email>", stderr)
+	out := addOutputFlag(fs)
+	positional, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	lc, err := newClient()
+	if err != nil {
+		return err
+	}
+	var user *client.User
+	if client.ValidateEmail(positional[0]) {
+		user, err = lc.GetUserByEmailCtx(ctx, positional[0])
+	} else {
+		user, err = lc.GetUserCtx(ctx, positional[0])
+	}
+	if err != nil {
+		return err
+	}
+	return out.print(stdout, user, func() table { return userTable(*user) })
+}
+
+func usersList(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var page, limit int
+	fs := newFlagSet("users list", "", stderr)
+	fs.IntVar(&page, "page", 1, "page number")
+	fs.IntVar(&limit, "limit", 10, "users per page")
+	out := addOutputFlag(fs)
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	lc, err := newClient()
+	if err != nil {
+		return err
+	}
+	users, err := lc.ListUsersCtx(ctx, page, limit)
+	if err != nil {
+		return err
+	}
+	return out.print(stdout, users, func() table { return userTable(users.Data...) })
+}
+
+func usersUpdate(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var req client.UpdateUserRequest
+	metadata := metadataFlag{}
+	fs := newFlagSet("users update", "<user-id>", stderr)
+	fs.StringVar(&req.Email, "email", "", "new email address")
+	fs.StringVar(&req.Name, "name", "", "new display name")
+	fs.Var(metadata, "metadata", "metadata as key=value (repeatable)")
+	out := addOutputFlag(fs)
+	positional, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if len(metadata) > 0 {
+		req.Metadata = metadata
+	}
+
+	lc, err := newClient()
+	if err != nil {
+		return err
+	}
+	user, err := lc.UpdateUserCtx(ctx, positional[0], req)
+	if err != nil {
+		return err
+	}
+	return out.print(stdout, user, func() table { return userTable(*user) })
+}
+
+func usersDelete(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("users delete", "<user-id>", stderr)
+	out := addOutputFlag(fs)
+	positional, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	lc, err := newClient()
+	if err != nil {
+		return err
+	}
+	if err := lc.DeleteUserCtx(ctx, positional[0]); err != nil {
+		return err
+	}
+	return out.printDeleted(stdout, positional[0])
+}
+
+func usersLicenses(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var page, limit int
+	fs := newFlagSet("users licenses", "<user-id>", stderr)
+	fs.IntVar(&page, "page", 1, "page number")
+	fs.IntVar(&limit, "limit", 10, "licenses per page")
+	out := addOutputFlag(fs)
+	positional, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	lc, err := newClient()
+	if err != nil {
+		return err
+	}
+	licenses, err := lc.ListUserLicensesCtx(ctx, positional[0], page, limit)
+	if err != nil {
+		return err
+	}
+	return out.print(stdout, licenses, func() table { return licenseTable(licenses.Data...) })
+}
+
+func usersStats(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("users stats", "", stderr)
+	out := addOutputFlag(fs)
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	lc, err := newClient()
+	if err != nil {
+		return err
+	}
+	stats, err := lc.GetUserStatsCtx(ctx)
+	if err != nil {
+		return err
+	}
+	return out.print(stdout, stats, func() table {
+		return table{
+			headers: []string{"TOTAL", "ACTIVE", "INACTIVE"},
+			rows:    [][]string{{strconv.Itoa(stats.Total), strconv.Itoa(stats.Active), strconv.Itoa(stats.Inactive)}},
+		}
+	})
+}
+
+// userTable renders users as table rows
+func userTable(users ...client.User) table {
+	t := table{headers: []string{"ID", "EMAIL", "NAME", "CREATED"}}
+	for _, user := range users {
+		t.rows = append(t.rows, []string{user.ID, user.Email, orDash(user.Name), formatTime(user.CreatedAt)})
+	}
+	return t
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/licensechain/licensechain-go-sdk/client"
+)
+
+var webhookCommands = []command{
+	{"create", "Register a webhook endpoint", webhooksCreate},
+	{"get", "Show a webhook endpoint", webhooksGet},
+	{"list", "List webhook endpoints", webhooksList},
+	{"update", "Update a webhook endpoint", webhooksUpdate},
+	{"delete", "Delete a webhook endpoint", webhooksDelete},
+	{"rotate-secret", "Generate a new signing secret", webhooksRotateSecret},
+	{"test", "Send a test event to a webhook endpoint", webhooksTest},
+}
+
+// runWebhooks dispatches the webhooks subcommands
+func runWebhooks(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	return runGroup(ctx, "licensechain webhooks", webhookCommands, args, stdout, stderr)
+}
+
+func webhooksCreate(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var req client.CreateWebhookRequest
+	var events string
+	var secretStdin bool
+	fs := newFlagSet("webhooks create", "", stderr)
+	fs.StringVar(&req.URL, "url", "", "endpoint URL (required)")
+	fs.StringVar(&events, "events", "", "comma-separated event types (required)")
+	fs.BoolVar(&secretStdin, "secret-stdin", false, "read the signing secret from standard input (default generated by the server)")
+	out := addOutputFlag(fs)
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+	req.Events = splitList(events)
+	if secretStdin {
+		secret, err := readSecret()
+		if err != nil {
+			return err
+		}
+		req.Secret = secret
+	}
+
+	lc, err := newClient()
+	if err != nil {
+		return err
+	}
+	webhook, err := lc.CreateWebhookCtx(ctx, req)
+	if err != nil {
+		return err
+	}
+	return out.print(stdout, webhook, func() table { return webhookSecretTable(*webhook) })
+}
+
+func webhooksGet(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("webhooks get", "<webhook-id>", stderr)
+	out := addOutputFlag(fs)
+	positional, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	lc, err := newClient()
+	if err != nil {
+		return err
+	}
+	webhook, err := lc.GetWebhookCtx(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+	return out.print(stdout, webhook, func() table { return webhookTable(*webhook) })
+}
+
+func webhooksList(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var page, limit int
+	fs := newFlagSet("webhooks list", "", stderr)
+	fs.IntVar(&page, "page", 1, "page number")
+	fs.IntVar(&limit, "limit", 10, "webhooks per page")
+	out := addOutputFlag(fs)
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	lc, err := newClient()
+	if err != nil {
+		return err
+	}
+	webhooks, err := lc.ListWebhooksCtx(ctx, page, limit)
+	if err != nil {
+		return err
+	}
+	return out.print(stdout, webhooks, func() table { return webhookTable(webhooks.Data...) })
+}
+
+func webhooksUpdate(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var req client.UpdateWebhookRequest
+	var events string
+	fs := newFlagSet("webhooks update", "<webhook-id>", stderr)
+	fs.StringVar(&req.URL, "url", "", "new endpoint URL")
+	fs.StringVar(&events, "events", "", "new comma-separated event types")
+	out := addOutputFlag(fs)
+	positional, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	req.Events = splitList(events)
+
+	lc, err := newClient()
+	if err != nil {
+		return err
+	}
+	webhook, err := lc.UpdateWebhookCtx(ctx, positional[0], req)
+	if err != nil {
+		return err
+	}
+	return out.print(stdout, webhook, func() table { return webhookTable(*webhook) })
+}
+
+func webhooksDelete(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("webhooks delete", "<webhook-id>", stderr)
+	out := addOutputFlag(fs)
+	positional, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	lc, err := newClient()
+	if err != nil {
+		return err
+	}
+	if err := lc.DeleteWebhookCtx(ctx, positional[0]); err != nil {
+		return err
+	}
+	return out.printDeleted(stdout, positional[0])
+}
+
+func webhooksRotateSecret(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("webhooks rotate-secret", "<webhook-id>", stderr)
+	out := addOutputFlag(fs)
+	positional, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	lc, err := newClient()
+	if err != nil {
+		return err
+	}
+	webhook, err := lc.RotateWebhookSecretCtx(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+	return out.print(stdout, webhook, func() table { return webhookSecretTable(*webhook) })
+}
+
+func webhooksTest(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var eventType string
+	fs := newFlagSet("webhooks test", "<webhook-id>", stderr)
+	fs.StringVar(&eventType, "event", client.WebhookEvents.LicenseCreated, "event type to send")
+	out := addOutputFlag(fs)
+	positional, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	lc, err := newClient()
+	if err != nil {
+		return err
+	}
+	result, err := lc.SendTestWebhookCtx(ctx, positional[0], eventType)
+	if err != nil {
+		return err
+	}
+	return out.print(stdout, result, func() table {
+		return table{
+			headers: []string{"DELIVERED", "STATUS", "DURATION", "ERROR"},
+			rows: [][]string{{
+				strconv.FormatBool(result.Delivered),
+				strconv.Itoa(result.StatusCode),
+				strconv.FormatInt(result.DurationMs, 10) + "ms",
+				orDash(result.Error),
+			}},
+		}
+	})
+}
+
+// webhookTable renders webhook endpoints as table rows
+func webhookTable(webhooks ...client.Webhook) table {
+	t := table{headers: []string{"ID", "URL", "EVENTS", "CREATED"}}
+	for _, webhook := range webhooks {
+		t.rows = append(t.rows, []string{webhook.ID, webhook.URL, strings.Join(webhook.Events, ","), formatTime(webhook.CreatedAt)})
+	}
+	return t
+}
+
+// webhookSecretTable renders a webhook endpoint including its signing secret
+func webhookSecretTable(webhook client.Webhook) table {
+	t := webhookTable(webhook)
+	t.headers = append(t.headers, "SECRET")
+	t.rows[0] = append(t.rows[0], orDash(webhook.Secret))
+	return t
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"time"
 
 	"github.com/licensechain/licensechain-go-sdk/client"
@@ -13,7 +15,8 @@ func main() {
 	lc := client.CreateClient("your-api-key-here", "https://api.licensechain.app")
 
 	// Test basic functionality
-	fmt.Println("🚀 LicenseChain Go SDK - Basic Usage Example\n")
+	fmt.Println("🚀 LicenseChain Go SDK - Basic Usage Example")
+	fmt.Println()
 
 	// 1. Health Check
 	fmt.Println("🏥 Health Check:")
@@ -107,24 +110,21 @@ func main() {
 	fmt.Println("\n🔄 Webhook Handling:")
 
 	webhookHandler := client.NewWebhookHandler("webhook-secret", 300)
-
-	// Simulate a webhook event
-	webhookEvent := map[string]interface{}{
-		"id":   "evt_123",
-		"type": "license.created",
-		"data": map[string]interface{}{
-			"id":          "lic_123",
-			"user_id":     "user_123",
-			"product_id":  "prod_123",
-			"license_key": "ABCDEFGHIJKLMNOPQRSTUVWXYZ012345",
-			"status":      "active",
-			"created_at":  time.Now().Format(time.RFC3339),
-		},
-		"timestamp": time.Now().Format(time.RFC3339),
-		"signature": "signature_here",
+	webhookHandler.OnLicenseCreated(func(ctx context.Context, event client.LicenseEvent) error {
+		fmt.Printf("License created: %s\n", event.License.ID)
+		return nil
+	})
+
+	// Simulate a webhook delivery: the signature covers the exact raw body
+	body := []byte(`{"id":"evt_123","type":"license.created","data":{"id":"lic_123","user_id":"user_123","product_id":"prod_123","license_key":"ABCDEFGHIJKLMNOPQRSTUVWXYZ012345","status":"active"}}`)
+	sigHeader, err := client.CreateWebhookSignatureHeader(string(body), "webhook-secret", time.Now().Unix())
+	if err != nil {
+		log.Fatalf("Failed to sign webhook: %v", err)
 	}
+	headers := http.Header{}
+	headers.Set(client.DefaultWebhookSignatureHeader, sigHeader)
 
-	err = webhookHandler.ProcessEvent(webhookEvent)
+	err = webhookHandler.ProcessRaw(body, headers)
 	if err != nil {
 		log.Printf("Failed to process webhook event: %v", err)
 	} else {